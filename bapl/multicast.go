@@ -11,6 +11,7 @@ import (
 	"capnproto.org/go/capnp/v3"
 	"github.com/iykyk-syn/unison/bapl/batchmsg"
 	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/rebro"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -19,6 +20,22 @@ import (
 
 var defaultProtocolID = protocol.ID("/multicastpool/v0.0.1")
 
+const (
+	// batchVersion is the version of the protocol Batches are signed with.
+	batchVersion = 1
+	// batchKind is the kind of Batch signatures.
+	batchKind = "bapl/batch"
+)
+
+// BatchDomain returns the [crypto.Domain] Batches of the network are signed within.
+func BatchDomain(networkID rebro.NetworkID) crypto.Domain {
+	return crypto.Domain{
+		Network: networkID.String(),
+		Version: batchVersion,
+		Kind:    batchKind,
+	}
+}
+
 type FetchIncludersFn func() []peer.ID
 
 type MulticastPool struct {
@@ -33,7 +50,10 @@ type MulticastPool struct {
 	log *slog.Logger
 }
 
+// NewMulticastPool instantiates a new MulticastPool signing and verifying Batches within
+// the [BatchDomain] of the network.
 func NewMulticastPool(
+	networkID rebro.NetworkID,
 	pool BatchPool,
 	host host.Host,
 	includers FetchIncludersFn,
@@ -45,7 +65,7 @@ func NewMulticastPool(
 		host:       host,
 		includers:  includers,
 		verifier:   verifier,
-		signer:     signer,
+		signer:     crypto.NewDomainSigner(signer, BatchDomain(networkID)),
 		protocolID: defaultProtocolID,
 		log:        slog.With("module", "mcast-pool"),
 	}
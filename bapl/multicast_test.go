@@ -60,13 +60,50 @@ func TestMulticastPool(t *testing.T) {
 	}
 }
 
+func TestMulticastPoolDomain(t *testing.T) {
+	const nodeCount = 4
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(nodeCount)
+	require.NoError(t, err)
+
+	pools := make([]*MulticastPool, nodeCount)
+	for i := range nodeCount {
+		pools[i] = multicast(net.Hosts()[i], net)
+	}
+
+	// batch signed for another network is rejected
+	signer := newTestSigner()
+	replayed := randBatch()
+	replayed.Signature, err = crypto2.NewDomainSigner(signer, BatchDomain("other")).Sign(replayed.Data)
+	require.NoError(t, err)
+	require.NoError(t, pools[0].Push(ctx, replayed))
+
+	valid := randBatch()
+	require.NoError(t, pools[0].Push(ctx, valid))
+
+	for _, p := range pools[1:] {
+		// pulling awaits the batch to arrive
+		_, err := p.Pull(ctx, valid.Hash())
+		require.NoError(t, err)
+
+		size, err := p.Size(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, size)
+	}
+}
+
 func multicast(host host.Host, mocknet mocknet.Mocknet) *MulticastPool {
 	mem := NewMemPool()
-	mcast := NewMulticastPool(mem, host, mocknet.Peers, newTestSigner(), &verifier{})
+	mcast := NewMulticastPool(testNetworkID, mem, host, mocknet.Peers, newTestSigner(), &verifier{})
 	mcast.Start()
 	return mcast
 }
 
+const testNetworkID = "test"
+
 type verifier struct{}
 
 func (v verifier) Verify(context.Context, *Batch) (bool, error) {
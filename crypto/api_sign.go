@@ -8,13 +8,19 @@ type Signature struct {
 	Signer []byte
 }
 
+// Verifier encapsulates asymmetric cryptographic schema out of Broadcasting protocol logic for
+// parties verifying Signatures without producing them.
+type Verifier interface {
+	// Verify performs cryptographic Signature verification of the given data.
+	Verify([]byte, Signature) error
+}
+
 // Signer encapsulates and separates asymmetric cryptographic schema out of Broadcasting protocol
 // logic together with private key management.
 type Signer interface {
+	Verifier
 	// ID returns Signer identity like public key
 	ID() []byte
 	// Sign produces a cryptographic Signature over the given data with internally managed identity.
 	Sign([]byte) (Signature, error)
-	// Verify performs cryptographic Signature verification of the given data.
-	Verify([]byte, Signature) error
 }
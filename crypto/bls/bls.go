@@ -0,0 +1,205 @@
+// Package bls provides BLS12-381 keys with public keys in G1 and signatures in G2.
+//
+// Unlike ed25519, BLS signatures of many signers over the same message can be aggregated into
+// a single signature and verified at once against the aggregated public keys of the signers.
+// Such fast aggregate verification is only secure against rogue key attacks when every
+// public key is known to be owned by its signer, e.g. registered out of band in the
+// includers set, rather than taken from the network.
+package bls
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+
+	GG "github.com/cloudflare/circl/ecc/bls12381"
+	"github.com/cloudflare/circl/sign/bls"
+
+	"github.com/iykyk-syn/unison/crypto"
+)
+
+const (
+	KeyType = "bls12381"
+	// Code is the multicodec code of BLS12-381 public keys in G1.
+	Code = 0xea
+
+	// PublicKeySize is the size of the compressed public key in G1.
+	PublicKeySize = GG.G1SizeCompressed
+	// PrivateKeySize is the size of the private key scalar.
+	PrivateKeySize = GG.ScalarSize
+	// SignatureSize is the size of the compressed signature in G2.
+	SignatureSize = GG.G2SizeCompressed
+)
+
+func init() {
+	crypto.RegisterScheme(crypto.Scheme{
+		Name:       KeyType,
+		Code:       Code,
+		PubKeySize: PublicKeySize,
+		UnmarshalPubKey: func(b []byte) (crypto.PubKey, error) {
+			return BytesToPubKey(b)
+		},
+	})
+}
+
+type PublicKey []byte
+
+func (pubKey PublicKey) VerifySignature(msg, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+
+	key, err := pubKey.key()
+	if err != nil {
+		return false
+	}
+	return bls.Verify(key, msg, sig)
+}
+
+func (pubKey PublicKey) Equals(other []byte) bool {
+	// compressed encoding is canonical, so there is no need to decode the points
+	return bytes.Equal(pubKey, other)
+}
+
+func (pubKey PublicKey) Bytes() []byte {
+	return pubKey
+}
+
+func (pubKey PublicKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PublicKey) key() (*bls.PublicKey[bls.KeyG1SigG2], error) {
+	key := new(bls.PublicKey[bls.KeyG1SigG2])
+	if err := key.UnmarshalBinary(pubKey); err != nil {
+		return nil, err
+	}
+	if !key.Validate() {
+		return nil, errors.New("invalid public key")
+	}
+	return key, nil
+}
+
+type PrivateKey []byte
+
+func (privKey PrivateKey) Sign(msg []byte) ([]byte, error) {
+	key, err := privKey.key()
+	if err != nil {
+		return nil, err
+	}
+	return bls.Sign(key, msg), nil
+}
+
+func (privKey PrivateKey) PubKey() crypto.PubKey {
+	key, err := privKey.key()
+	if err != nil {
+		// keys are validated on construction
+		panic(err)
+	}
+
+	public, err := key.PublicKey().MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return PublicKey(public)
+}
+
+func (privKey PrivateKey) Equals(other []byte) bool {
+	if len(other) != PrivateKeySize {
+		return false
+	}
+	return subtle.ConstantTimeCompare(privKey, other) == 1
+}
+
+func (privKey PrivateKey) Type() string {
+	return KeyType
+}
+
+func (privKey PrivateKey) key() (*bls.PrivateKey[bls.KeyG1SigG2], error) {
+	key := new(bls.PrivateKey[bls.KeyG1SigG2])
+	if err := key.UnmarshalBinary(privKey); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func GenKeys() (PublicKey, PrivateKey, error) {
+	ikm := make([]byte, 32)
+	if _, err := rand.Read(ikm); err != nil {
+		return nil, nil, err
+	}
+
+	key, err := bls.KeyGen[bls.KeyG1SigG2](ikm, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	private, err := key.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	public, err := key.PublicKey().MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return public, private, nil
+}
+
+func BytesToPubKey(b []byte) (PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, errors.New("invalid key length")
+	}
+
+	key := make(PublicKey, PublicKeySize)
+	copy(key, b)
+	if _, err := key.key(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func BytesToPrivKey(b []byte) (PrivateKey, error) {
+	if len(b) != PrivateKeySize {
+		return nil, errors.New("invalid key length")
+	}
+
+	key := make(PrivateKey, PrivateKeySize)
+	copy(key, b)
+	if _, err := key.key(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Aggregate aggregates signatures into a single one.
+func Aggregate(sigs [][]byte) ([]byte, error) {
+	for _, sig := range sigs {
+		if len(sig) != SignatureSize {
+			return nil, errors.New("invalid signature length")
+		}
+	}
+	return bls.Aggregate(bls.KeyG1SigG2{}, sigs)
+}
+
+// FastAggregateVerify verifies the aggregated signature of all the given public keys over
+// the same message with a single pairing check.
+func FastAggregateVerify(pubKeys []PublicKey, msg, aggSig []byte) bool {
+	if len(pubKeys) == 0 {
+		return false
+	}
+
+	var sum, point GG.G1
+	sum.SetIdentity()
+	for _, pubKey := range pubKeys {
+		// validates the key is in the subgroup
+		if _, err := pubKey.key(); err != nil {
+			return false
+		}
+		if err := point.SetBytes(pubKey); err != nil {
+			return false
+		}
+		sum.Add(&sum, &point)
+	}
+	return PublicKey(sum.BytesCompressed()).VerifySignature(msg, aggSig)
+}
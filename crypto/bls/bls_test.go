@@ -0,0 +1,131 @@
+package bls_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/bls"
+)
+
+// infinity is the compressed encoding of the point at infinity in G1 or G2 of the given size.
+func infinity(size int) []byte {
+	point := make([]byte, size)
+	point[0] = 0xc0
+	return point
+}
+
+func TestSignVerify(t *testing.T) {
+	pubKey, privKey, err := bls.GenKeys()
+	require.NoError(t, err)
+	otherPub, _, err := bls.GenKeys()
+	require.NoError(t, err)
+
+	msg := []byte("msg")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	assert.Len(t, sig, bls.SignatureSize)
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+	assert.True(t, privKey.PubKey().Equals(pubKey))
+
+	assert.False(t, pubKey.VerifySignature([]byte("other"), sig))
+	assert.False(t, otherPub.VerifySignature(msg, sig))
+	assert.False(t, pubKey.VerifySignature(msg, sig[:len(sig)-1]))
+	assert.False(t, pubKey.VerifySignature(msg, infinity(bls.SignatureSize)))
+
+	signer, err := bls.NewSigner(privKey)
+	require.NoError(t, err)
+	signature, err := signer.Sign(msg)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(pubKey), signature.Signer)
+	require.NoError(t, bls.NewVerifier().Verify(msg, signature))
+	assert.Error(t, bls.NewVerifier().Verify(msg, crypto.Signature{Body: signature.Body, Signer: otherPub}))
+}
+
+func TestInvalidKeys(t *testing.T) {
+	pubKey, privKey, err := bls.GenKeys()
+	require.NoError(t, err)
+
+	decodedPub, err := bls.BytesToPubKey(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, pubKey, decodedPub)
+	decodedPriv, err := bls.BytesToPrivKey(privKey)
+	require.NoError(t, err)
+	assert.True(t, decodedPriv.Equals(privKey))
+
+	_, err = bls.BytesToPubKey(pubKey[:len(pubKey)-1])
+	assert.Error(t, err)
+	_, err = bls.BytesToPubKey(infinity(bls.PublicKeySize))
+	assert.Error(t, err)
+	// x coordinate with no point on the curve
+	notOnCurve := make([]byte, bls.PublicKeySize)
+	notOnCurve[0] = 0x80
+	notOnCurve[len(notOnCurve)-1] = 5
+	_, err = bls.BytesToPubKey(notOnCurve)
+	assert.Error(t, err)
+	// uncompressed flag is not accepted for compressed keys
+	uncompressed := append([]byte(nil), pubKey...)
+	uncompressed[0] &^= 0x80
+	_, err = bls.BytesToPubKey(uncompressed)
+	assert.Error(t, err)
+
+	_, err = bls.BytesToPrivKey(privKey[:len(privKey)-1])
+	assert.Error(t, err)
+	_, err = bls.NewSigner(make(bls.PrivateKey, bls.PrivateKeySize-1))
+	assert.Error(t, err)
+
+	msg := []byte("msg")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	assert.False(t, bls.PublicKey(infinity(bls.PublicKeySize)).VerifySignature(msg, sig))
+	assert.False(t, bls.PublicKey(notOnCurve).VerifySignature(msg, sig))
+}
+
+func TestAggregate(t *testing.T) {
+	const count = 5
+	msg := []byte("msg")
+
+	pubKeys := make([]bls.PublicKey, count)
+	sigs := make([][]byte, count)
+	for i := range count {
+		pubKey, privKey, err := bls.GenKeys()
+		require.NoError(t, err)
+		pubKeys[i] = pubKey
+		sigs[i], err = privKey.Sign(msg)
+		require.NoError(t, err)
+	}
+
+	aggSig, err := bls.Aggregate(sigs)
+	require.NoError(t, err)
+	assert.Len(t, aggSig, bls.SignatureSize)
+	assert.True(t, bls.FastAggregateVerify(pubKeys, msg, aggSig))
+	// the order of keys does not matter
+	reversed := []bls.PublicKey{pubKeys[4], pubKeys[3], pubKeys[2], pubKeys[1], pubKeys[0]}
+	assert.True(t, bls.FastAggregateVerify(reversed, msg, aggSig))
+
+	assert.False(t, bls.FastAggregateVerify(pubKeys, []byte("other"), aggSig))
+	assert.False(t, bls.FastAggregateVerify(nil, msg, aggSig))
+	// missing, foreign and repeated keys
+	assert.False(t, bls.FastAggregateVerify(pubKeys[1:], msg, aggSig))
+	otherPub, otherPriv, err := bls.GenKeys()
+	require.NoError(t, err)
+	assert.False(t, bls.FastAggregateVerify(append(pubKeys[1:], otherPub), msg, aggSig))
+	assert.False(t, bls.FastAggregateVerify(append(pubKeys[1:], pubKeys[1]), msg, aggSig))
+	// invalid keys
+	assert.False(t, bls.FastAggregateVerify(append(pubKeys[:count:count], infinity(bls.PublicKeySize)), msg, aggSig))
+	assert.False(t, bls.FastAggregateVerify(append(pubKeys[:count:count], pubKeys[0][1:]), msg, aggSig))
+
+	// a signature over another message spoils the aggregate
+	otherSig, err := otherPriv.Sign([]byte("other"))
+	require.NoError(t, err)
+	spoiled, err := bls.Aggregate(append(sigs[1:], otherSig))
+	require.NoError(t, err)
+	assert.False(t, bls.FastAggregateVerify(append(pubKeys[1:], otherPub), msg, spoiled))
+
+	_, err = bls.Aggregate(append(sigs[1:], sigs[0][1:]))
+	assert.Error(t, err)
+	_, err = bls.Aggregate(append(sigs[1:], make([]byte, bls.SignatureSize)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package bls
+
+import (
+	"errors"
+
+	"github.com/iykyk-syn/unison/crypto"
+)
+
+// Signer is a [crypto.Signer] producing aggregatable BLS signatures.
+type Signer struct {
+	privKey PrivateKey
+	pubKey  PublicKey
+}
+
+func NewSigner(privKey PrivateKey) (*Signer, error) {
+	if _, err := privKey.key(); err != nil {
+		return nil, err
+	}
+
+	return &Signer{
+		privKey: privKey,
+		pubKey:  privKey.PubKey().(PublicKey),
+	}, nil
+}
+
+func (s *Signer) ID() []byte {
+	return s.pubKey.Bytes()
+}
+
+func (s *Signer) Sign(msg []byte) (crypto.Signature, error) {
+	signature, err := s.privKey.Sign(msg)
+	if err != nil {
+		return crypto.Signature{}, err
+	}
+
+	return crypto.Signature{
+		Signer: s.ID(),
+		Body:   signature,
+	}, nil
+}
+
+func (s *Signer) Verify(msg []byte, signature crypto.Signature) error {
+	return Verifier{}.Verify(msg, signature)
+}
+
+// Verifier verifies signatures produced by [Signer] without holding any private key.
+type Verifier struct{}
+
+func NewVerifier() Verifier {
+	return Verifier{}
+}
+
+func (Verifier) Verify(msg []byte, signature crypto.Signature) error {
+	ok := PublicKey(signature.Signer).VerifySignature(msg, signature.Body)
+	if !ok {
+		return errors.New("signature is invalid")
+	}
+	return nil
+}
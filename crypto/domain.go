@@ -0,0 +1,69 @@
+package crypto
+
+import "encoding/binary"
+
+// domainPrefix starts every domain tagged payload.
+const domainPrefix = "unison/domain"
+
+// Domain separates Signatures produced for different networks, protocol versions and message
+// kinds, so that a Signature produced within one Domain is never valid within another one,
+// even though produced with the same key.
+type Domain struct {
+	// Network identifies the network Signatures are produced for.
+	Network string
+	// Version of the protocol Signatures are produced with.
+	Version uint16
+	// Kind of the signed messages.
+	Kind string
+}
+
+// Tag binds the data to the Domain, producing the payload to be signed or verified.
+func (d Domain) Tag(data []byte) []byte {
+	payload := make([]byte, 0, len(domainPrefix)+len(d.Network)+len(d.Kind)+len(data)+10)
+	payload = append(payload, domainPrefix...)
+	payload = binary.BigEndian.AppendUint32(payload, uint32(len(d.Network)))
+	payload = append(payload, d.Network...)
+	payload = binary.BigEndian.AppendUint16(payload, d.Version)
+	payload = binary.BigEndian.AppendUint32(payload, uint32(len(d.Kind)))
+	payload = append(payload, d.Kind...)
+	return append(payload, data...)
+}
+
+// DomainSigner is a Signer producing and verifying Signatures within the Domain.
+type DomainSigner struct {
+	Signer
+	domain Domain
+}
+
+// NewDomainSigner wraps the Signer to produce and verify Signatures within the Domain.
+func NewDomainSigner(signer Signer, domain Domain) *DomainSigner {
+	return &DomainSigner{Signer: signer, domain: domain}
+}
+
+// Sign produces a Signature over the data bound to the Domain.
+func (s *DomainSigner) Sign(data []byte) (Signature, error) {
+	return s.Signer.Sign(s.domain.Tag(data))
+}
+
+// Verify verifies the Signature over the data bound to the Domain.
+// Signatures produced within other Domains are rejected.
+func (s *DomainSigner) Verify(data []byte, signature Signature) error {
+	return s.Signer.Verify(s.domain.Tag(data), signature)
+}
+
+// DomainVerifier is a Verifier of Signatures produced within the Domain.
+type DomainVerifier struct {
+	verifier Verifier
+	domain   Domain
+}
+
+// NewDomainVerifier wraps the Verifier to verify Signatures produced within the Domain.
+func NewDomainVerifier(verifier Verifier, domain Domain) *DomainVerifier {
+	return &DomainVerifier{verifier: verifier, domain: domain}
+}
+
+// Verify verifies the Signature over the data bound to the Domain.
+// Signatures produced within other Domains are rejected.
+func (v *DomainVerifier) Verify(data []byte, signature Signature) error {
+	return v.verifier.Verify(v.domain.Tag(data), signature)
+}
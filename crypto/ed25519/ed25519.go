@@ -11,12 +11,26 @@ import (
 
 const (
 	KeyType = "ed25519"
+	// Code is the multicodec code of ed25519 public keys.
+	Code = 0xed
 )
 
+func init() {
+	crypto.RegisterScheme(crypto.Scheme{
+		Name:       KeyType,
+		Code:       Code,
+		PubKeySize: ed25519.PublicKeySize,
+		UnmarshalPubKey: func(b []byte) (crypto.PubKey, error) {
+			return BytesToPubKey(b)
+		},
+	})
+}
+
 type PublicKey []byte
 
 func (pubKey PublicKey) VerifySignature(msg, sig []byte) bool {
-	if len(sig) != ed25519.SignatureSize {
+	// ed25519.Verify panics on keys of other sizes
+	if len(pubKey) != ed25519.PublicKeySize || len(sig) != ed25519.SignatureSize {
 		return false
 	}
 	return ed25519.Verify(ed25519.PublicKey(pubKey), msg, sig)
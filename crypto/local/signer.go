@@ -5,6 +5,7 @@ import (
 
 	"github.com/iykyk-syn/unison/crypto"
 	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/crypto/secp256k1"
 )
 
 type Signer struct {
@@ -41,7 +42,36 @@ func (s *Signer) Sign(msg []byte) (crypto.Signature, error) {
 }
 
 func (s *Signer) Verify(msg []byte, signature crypto.Signature) error {
-	pubK := ed25519.PublicKey(signature.Signer)
+	if s.pubKey.Equals(signature.Signer) {
+		// own signatures are verified with the own scheme
+		if !s.pubKey.VerifySignature(msg, signature.Body) {
+			return errors.New("signature is invalid")
+		}
+		return nil
+	}
+	return Verifier{}.Verify(msg, signature)
+}
+
+// Verifier verifies signatures produced by [Signer] without holding any private key.
+// Signers with self-describing public keys are verified with their registered [crypto.Scheme],
+// while raw ones are told apart by size, being either ed25519 or compressed secp256k1.
+type Verifier struct{}
+
+func NewVerifier() Verifier {
+	return Verifier{}
+}
+
+func (Verifier) Verify(msg []byte, signature crypto.Signature) error {
+	// self-describing signers are verified with their scheme, while raw ones by their size
+	pubK, err := crypto.UnmarshalPubKey(signature.Signer)
+	if err != nil {
+		switch len(signature.Signer) {
+		case secp256k1.PublicKeySize:
+			pubK = secp256k1.PublicKey(signature.Signer)
+		default:
+			pubK = ed25519.PublicKey(signature.Signer)
+		}
+	}
 	ok := pubK.VerifySignature(msg, signature.Body)
 	if !ok {
 		return errors.New("signature is invalid")
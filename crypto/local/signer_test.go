@@ -0,0 +1,54 @@
+package local_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/crypto/local"
+	"github.com/iykyk-syn/unison/crypto/secp256k1"
+)
+
+func TestVerifierRawKeys(t *testing.T) {
+	_, secpPriv, err := secp256k1.GenKeys()
+	require.NoError(t, err)
+	_, edPriv, err := ed25519.GenKeys()
+	require.NoError(t, err)
+
+	secpSigner, err := local.NewSigner(secpPriv)
+	require.NoError(t, err)
+	edSigner, err := local.NewSigner(edPriv)
+	require.NoError(t, err)
+
+	msg := []byte("msg")
+	verifier := local.NewVerifier()
+
+	// raw 33-byte signers fall back to compressed secp256k1 keys
+	secpSig, err := secpSigner.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, secpSig.Signer, secp256k1.PublicKeySize)
+	require.NoError(t, verifier.Verify(msg, secpSig))
+	require.NoError(t, edSigner.Verify(msg, secpSig))
+	assert.Error(t, verifier.Verify([]byte("other"), secpSig))
+
+	edSig, err := edSigner.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(msg, edSig))
+	require.NoError(t, secpSigner.Verify(msg, edSig))
+
+	// signatures are bound to the scheme of their signer
+	assert.Error(t, verifier.Verify(msg, crypto.Signature{Body: secpSig.Body, Signer: edSig.Signer}))
+	assert.Error(t, verifier.Verify(msg, crypto.Signature{Body: edSig.Body, Signer: secpSig.Signer}))
+
+	// malformed and incorrectly sized signers are rejected
+	malformed := append([]byte(nil), secpSig.Signer...)
+	malformed[0] = 0x05
+	assert.Error(t, verifier.Verify(msg, crypto.Signature{Body: secpSig.Body, Signer: malformed}))
+	for _, signer := range [][]byte{nil, secpSig.Signer[1:], append(secpSig.Signer, 0), edSig.Signer[1:]} {
+		assert.Error(t, verifier.Verify(msg, crypto.Signature{Body: secpSig.Body, Signer: signer}))
+		assert.Error(t, verifier.Verify(msg, crypto.Signature{Body: edSig.Body, Signer: signer}))
+	}
+}
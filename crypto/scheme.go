@@ -0,0 +1,222 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Scheme describes a public-key cryptography scheme, so that its keys and signatures are
+// self-describing and schemes can be changed or coexist over the network lifetime.
+type Scheme struct {
+	// Name is the name of the scheme matching [PubKey.Type].
+	Name string
+	// Code is the multicodec code of the scheme's public keys prefixing their self-describing
+	// encoding.
+	Code uint64
+	// PubKeySize is the size of the scheme's public keys.
+	PubKeySize int
+	// UnmarshalPubKey decodes the public key from the bytes of PubKeySize.
+	UnmarshalPubKey func([]byte) (PubKey, error)
+}
+
+var (
+	schemesMu     sync.RWMutex
+	schemesByName = make(map[string]Scheme)
+	schemesByCode = make(map[uint64]Scheme)
+)
+
+// RegisterScheme registers the Scheme, which is usually done in the init function of the package
+// implementing it. It panics if a scheme with the same name or code is already registered.
+func RegisterScheme(scheme Scheme) {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+
+	if _, ok := schemesByName[scheme.Name]; ok {
+		panic(fmt.Sprintf("crypto: scheme %s is already registered", scheme.Name))
+	}
+	if _, ok := schemesByCode[scheme.Code]; ok {
+		panic(fmt.Sprintf("crypto: scheme code 0x%x is already registered", scheme.Code))
+	}
+	schemesByName[scheme.Name] = scheme
+	schemesByCode[scheme.Code] = scheme
+}
+
+// SchemeByName returns the registered Scheme by its name.
+func SchemeByName(name string) (Scheme, bool) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+
+	scheme, ok := schemesByName[name]
+	return scheme, ok
+}
+
+// SchemeByCode returns the registered Scheme by its multicodec code.
+func SchemeByCode(code uint64) (Scheme, bool) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+
+	scheme, ok := schemesByCode[code]
+	return scheme, ok
+}
+
+// MarshalPubKey encodes the public key prefixed with the multicodec code of its Scheme.
+func MarshalPubKey(pubKey PubKey) ([]byte, error) {
+	if described, ok := pubKey.(*DescribedPubKey); ok {
+		return described.Bytes(), nil
+	}
+
+	scheme, ok := SchemeByName(pubKey.Type())
+	if !ok {
+		return nil, fmt.Errorf("unknown scheme %s", pubKey.Type())
+	}
+	return append(binary.AppendUvarint(nil, scheme.Code), pubKey.Bytes()...), nil
+}
+
+// UnmarshalPubKey decodes the public key from its self-describing encoding.
+func UnmarshalPubKey(data []byte) (PubKey, error) {
+	scheme, key, err := splitPrefix(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != scheme.PubKeySize {
+		return nil, fmt.Errorf("invalid %s key length %d", scheme.Name, len(key))
+	}
+	return scheme.UnmarshalPubKey(key)
+}
+
+// MarshalSignature encodes the Signature as the self-describing public key of the signer followed
+// by the signature body. The signer must be self-describing, e.g. produced by [DescribedSigner].
+func MarshalSignature(sig Signature) ([]byte, error) {
+	if _, err := UnmarshalPubKey(sig.Signer); err != nil {
+		return nil, fmt.Errorf("decoding signer: %w", err)
+	}
+
+	data := make([]byte, 0, len(sig.Signer)+len(sig.Body))
+	return append(append(data, sig.Signer...), sig.Body...), nil
+}
+
+// UnmarshalSignature decodes the Signature from its self-describing encoding.
+// The signer of the Signature is self-describing.
+func UnmarshalSignature(data []byte) (Signature, error) {
+	scheme, key, err := splitPrefix(data)
+	if err != nil {
+		return Signature{}, err
+	}
+	if len(key) < scheme.PubKeySize {
+		return Signature{}, fmt.Errorf("invalid %s signature length %d", scheme.Name, len(key))
+	}
+
+	prefixLen := len(data) - len(key)
+	return Signature{
+		Signer: data[:prefixLen+scheme.PubKeySize],
+		Body:   data[prefixLen+scheme.PubKeySize:],
+	}, nil
+}
+
+// DescribedPubKey is a [PubKey] identified by its self-describing encoding.
+type DescribedPubKey struct {
+	PubKey
+	encoded []byte
+}
+
+// Describe wraps the public key to be identified by its self-describing encoding.
+func Describe(pubKey PubKey) (*DescribedPubKey, error) {
+	encoded, err := MarshalPubKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribedPubKey{PubKey: pubKey, encoded: encoded}, nil
+}
+
+func (pubKey *DescribedPubKey) Bytes() []byte {
+	return pubKey.encoded
+}
+
+func (pubKey *DescribedPubKey) Equals(other []byte) bool {
+	return bytes.Equal(pubKey.encoded, other)
+}
+
+// SchemeVerifier is a [Verifier] of Signatures with self-describing signers, which dispatches
+// verification on the Scheme of the signer, so that schemes coexist in the network.
+type SchemeVerifier struct{}
+
+func NewSchemeVerifier() SchemeVerifier {
+	return SchemeVerifier{}
+}
+
+func (SchemeVerifier) Verify(msg []byte, signature Signature) error {
+	pubKey, err := UnmarshalPubKey(signature.Signer)
+	if err != nil {
+		return fmt.Errorf("decoding signer: %w", err)
+	}
+	if !pubKey.VerifySignature(msg, signature.Body) {
+		return errors.New("signature is invalid")
+	}
+	return nil
+}
+
+// DescribedSigner is a [Signer] identified by its self-describing public key.
+type DescribedSigner struct {
+	signer Signer
+	id     []byte
+}
+
+// NewDescribedSigner wraps the signer of the scheme to be identified by its self-describing
+// public key.
+func NewDescribedSigner(signer Signer, scheme string) (*DescribedSigner, error) {
+	pubKey, err := unmarshalRaw(scheme, signer.ID())
+	if err != nil {
+		return nil, err
+	}
+	id, err := MarshalPubKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribedSigner{signer: signer, id: id}, nil
+}
+
+func (s *DescribedSigner) ID() []byte {
+	return s.id
+}
+
+func (s *DescribedSigner) Sign(msg []byte) (Signature, error) {
+	sig, err := s.signer.Sign(msg)
+	if err != nil {
+		return Signature{}, err
+	}
+	sig.Signer = s.id
+	return sig, nil
+}
+
+func (s *DescribedSigner) Verify(msg []byte, signature Signature) error {
+	return SchemeVerifier{}.Verify(msg, signature)
+}
+
+// unmarshalRaw decodes the raw public key of the scheme.
+func unmarshalRaw(name string, key []byte) (PubKey, error) {
+	scheme, ok := SchemeByName(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown scheme %s", name)
+	}
+	if len(key) != scheme.PubKeySize {
+		return nil, fmt.Errorf("invalid %s key length %d", scheme.Name, len(key))
+	}
+	return scheme.UnmarshalPubKey(key)
+}
+
+// splitPrefix splits the multicodec prefix off the data returning the registered Scheme.
+func splitPrefix(data []byte) (Scheme, []byte, error) {
+	code, n := binary.Uvarint(data)
+	// the prefix must be minimally encoded, so that every key has exactly one encoding
+	if n <= 0 || n != len(binary.AppendUvarint(nil, code)) {
+		return Scheme{}, nil, errors.New("invalid multicodec prefix")
+	}
+	scheme, ok := SchemeByCode(code)
+	if !ok {
+		return Scheme{}, nil, fmt.Errorf("unknown multicodec code 0x%x", code)
+	}
+	return scheme, data[n:], nil
+}
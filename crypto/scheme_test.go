@@ -0,0 +1,100 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/bls"
+	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/crypto/local"
+	"github.com/iykyk-syn/unison/crypto/secp256k1"
+)
+
+func TestSchemes(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenKeys()
+	require.NoError(t, err)
+	blsPub, blsPriv, err := bls.GenKeys()
+	require.NoError(t, err)
+	secpPub, secpPriv, err := secp256k1.GenKeys()
+	require.NoError(t, err)
+
+	for _, pubKey := range []crypto.PubKey{edPub, blsPub, secpPub} {
+		data, err := crypto.MarshalPubKey(pubKey)
+		require.NoError(t, err)
+		decoded, err := crypto.UnmarshalPubKey(data)
+		require.NoError(t, err)
+		assert.Equal(t, pubKey.Type(), decoded.Type())
+		assert.Equal(t, pubKey.Bytes(), decoded.Bytes())
+
+		_, err = crypto.UnmarshalPubKey(data[:len(data)-1])
+		assert.Error(t, err)
+		// non-minimal prefix of the same code
+		_, err = crypto.UnmarshalPubKey(append([]byte{data[0], data[1] | 0x80, 0}, data[2:]...))
+		assert.Error(t, err)
+	}
+	_, err = crypto.UnmarshalPubKey(append([]byte{0x01}, edPub...))
+	assert.Error(t, err)
+
+	// signers of different schemes coexist under the same verifier
+	verifier := crypto.NewSchemeVerifier()
+	msg := []byte("msg")
+	for scheme, privKey := range map[string]crypto.PrivKey{
+		ed25519.KeyType:   edPriv,
+		bls.KeyType:       blsPriv,
+		secp256k1.KeyType: secpPriv,
+	} {
+		localSigner, err := local.NewSigner(privKey)
+		require.NoError(t, err)
+		signer, err := crypto.NewDescribedSigner(localSigner, scheme)
+		require.NoError(t, err)
+
+		sig, err := signer.Sign(msg)
+		require.NoError(t, err)
+		assert.Equal(t, signer.ID(), sig.Signer)
+		require.NoError(t, verifier.Verify(msg, sig))
+		require.NoError(t, signer.Verify(msg, sig))
+		require.NoError(t, local.NewVerifier().Verify(msg, sig))
+		assert.Error(t, verifier.Verify([]byte("other"), sig))
+
+		data, err := crypto.MarshalSignature(sig)
+		require.NoError(t, err)
+		decoded, err := crypto.UnmarshalSignature(data)
+		require.NoError(t, err)
+		assert.Equal(t, sig, decoded)
+
+		described, err := crypto.UnmarshalPubKey(sig.Signer)
+		require.NoError(t, err)
+		assert.Equal(t, scheme, described.Type())
+	}
+
+	// raw signers are still verified by the local verifier
+	for _, privKey := range []crypto.PrivKey{edPriv, secpPriv} {
+		localSigner, err := local.NewSigner(privKey)
+		require.NoError(t, err)
+		sig, err := localSigner.Sign(msg)
+		require.NoError(t, err)
+		require.NoError(t, local.NewVerifier().Verify(msg, sig))
+		_, err = crypto.MarshalSignature(sig)
+		assert.Error(t, err)
+	}
+
+	// secp256k1 signatures are deterministic and bound to the key
+	sig1, err := secpPriv.Sign(msg)
+	require.NoError(t, err)
+	sig2, err := secpPriv.Sign(msg)
+	require.NoError(t, err)
+	assert.Equal(t, sig1, sig2)
+	otherPub, _, err := secp256k1.GenKeys()
+	require.NoError(t, err)
+	assert.False(t, otherPub.VerifySignature(msg, sig1))
+	decodedPriv, err := secp256k1.BytesToPrivKey(secpPriv)
+	require.NoError(t, err)
+	assert.True(t, decodedPriv.PubKey().Equals(secpPub))
+	_, err = secp256k1.BytesToPrivKey(make([]byte, secp256k1.PrivateKeySize))
+	assert.Error(t, err)
+	_, err = secp256k1.BytesToPubKey(make([]byte, secp256k1.PublicKeySize))
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+// Package secp256k1 provides secp256k1 keys with compressed public keys and deterministic
+// ECDSA signatures.
+//
+// Signatures follow ECDSA over the SHA-256 digest of the message with RFC6979 nonces, as libp2p
+// secp256k1 identities do. ECDSA is chosen over Schnorr variants, as it is what Ethereum-style
+// tooling, HSMs and wallets produce and verify for secp256k1 keys, while EC-Schnorr-DCRv0 is
+// specific to Decred and BIP-340 binds signatures to x-only keys. Signatures are encoded as R || S
+// without the recovery byte and S is required to be in the lower half of the order, so that
+// signing the same message with the same key always produces the same signature and signatures
+// are not malleable.
+package secp256k1
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/iykyk-syn/unison/crypto"
+)
+
+const (
+	KeyType = "secp256k1"
+	// Code is the multicodec code of compressed secp256k1 public keys.
+	Code = 0xe7
+
+	// PublicKeySize is the size of the compressed public key.
+	PublicKeySize = secp256k1.PubKeyBytesLenCompressed
+	// PrivateKeySize is the size of the private key scalar.
+	PrivateKeySize = secp256k1.PrivKeyBytesLen
+	// SignatureSize is the size of the R || S encoded ECDSA signature.
+	SignatureSize = 64
+)
+
+func init() {
+	crypto.RegisterScheme(crypto.Scheme{
+		Name:       KeyType,
+		Code:       Code,
+		PubKeySize: PublicKeySize,
+		UnmarshalPubKey: func(b []byte) (crypto.PubKey, error) {
+			return BytesToPubKey(b)
+		},
+	})
+}
+
+type PublicKey []byte
+
+func (pubKey PublicKey) VerifySignature(msg, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	key, err := pubKey.key()
+	if err != nil {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+	if overflow := r.SetByteSlice(sig[:32]); overflow || r.IsZero() {
+		return false
+	}
+	// the high S counterpart of every valid signature is rejected for non-malleability
+	if overflow := s.SetByteSlice(sig[32:]); overflow || s.IsZero() || s.IsOverHalfOrder() {
+		return false
+	}
+
+	digest := sha256.Sum256(msg)
+	return ecdsa.NewSignature(&r, &s).Verify(digest[:], key)
+}
+
+func (pubKey PublicKey) Equals(other []byte) bool {
+	return subtle.ConstantTimeCompare(pubKey, other) == 1
+}
+
+func (pubKey PublicKey) Bytes() []byte {
+	return pubKey
+}
+
+func (pubKey PublicKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PublicKey) key() (*secp256k1.PublicKey, error) {
+	if len(pubKey) != PublicKeySize {
+		return nil, errors.New("invalid key length")
+	}
+	return secp256k1.ParsePubKey(pubKey)
+}
+
+type PrivateKey []byte
+
+func (privKey PrivateKey) Sign(msg []byte) ([]byte, error) {
+	key, err := privKey.key()
+	if err != nil {
+		return nil, err
+	}
+	defer key.Zero()
+
+	digest := sha256.Sum256(msg)
+	// the compact encoding is the recovery byte followed by R || S with S in the lower half
+	compact := ecdsa.SignCompact(key, digest[:], true)
+	return compact[1:], nil
+}
+
+func (privKey PrivateKey) PubKey() crypto.PubKey {
+	key, err := privKey.key()
+	if err != nil {
+		// keys are validated on construction
+		panic(err)
+	}
+	defer key.Zero()
+
+	return PublicKey(key.PubKey().SerializeCompressed())
+}
+
+func (privKey PrivateKey) Equals(other []byte) bool {
+	return subtle.ConstantTimeCompare(privKey, other) == 1
+}
+
+func (privKey PrivateKey) Type() string {
+	return KeyType
+}
+
+func (privKey PrivateKey) key() (*secp256k1.PrivateKey, error) {
+	if len(privKey) != PrivateKeySize {
+		return nil, errors.New("invalid key length")
+	}
+
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(privKey); overflow || scalar.IsZero() {
+		return nil, errors.New("invalid private key")
+	}
+	return secp256k1.NewPrivateKey(&scalar), nil
+}
+
+func GenKeys() (PublicKey, PrivateKey, error) {
+	key, err := secp256k1.GeneratePrivateKeyFromRand(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer key.Zero()
+
+	return key.PubKey().SerializeCompressed(), key.Serialize(), nil
+}
+
+func BytesToPubKey(b []byte) (PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, errors.New("invalid key length")
+	}
+
+	key := make(PublicKey, PublicKeySize)
+	copy(key, b)
+	if _, err := key.key(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func BytesToPrivKey(b []byte) (PrivateKey, error) {
+	if len(b) != PrivateKeySize {
+		return nil, errors.New("invalid key length")
+	}
+
+	key := make(PrivateKey, PrivateKeySize)
+	copy(key, b)
+	if _, err := key.key(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,143 @@
+package secp256k1_test
+
+import (
+	"testing"
+
+	dcrsecp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto/secp256k1"
+)
+
+func TestSignVerify(t *testing.T) {
+	pubKey, privKey, err := secp256k1.GenKeys()
+	require.NoError(t, err)
+	assert.Len(t, pubKey, secp256k1.PublicKeySize)
+	assert.Len(t, privKey, secp256k1.PrivateKeySize)
+	assert.True(t, privKey.PubKey().Equals(pubKey))
+
+	msg := []byte("msg")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	assert.Len(t, sig, secp256k1.SignatureSize)
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+
+	// signatures are deterministic
+	again, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	assert.Equal(t, sig, again)
+	other, err := privKey.Sign([]byte("other"))
+	require.NoError(t, err)
+	assert.NotEqual(t, sig, other)
+
+	// tampered messages and signatures
+	assert.False(t, pubKey.VerifySignature([]byte("other"), sig))
+	tampered := append([]byte(nil), sig...)
+	tampered[len(tampered)-1] ^= 1
+	assert.False(t, pubKey.VerifySignature(msg, tampered))
+	assert.False(t, pubKey.VerifySignature(msg, sig[:len(sig)-1]))
+	assert.False(t, pubKey.VerifySignature(msg, append(sig, 0)))
+
+	// wrong keys
+	otherPub, _, err := secp256k1.GenKeys()
+	require.NoError(t, err)
+	assert.False(t, otherPub.VerifySignature(msg, sig))
+	assert.False(t, secp256k1.PublicKey(pubKey[1:]).VerifySignature(msg, sig))
+}
+
+func TestInvalidKeys(t *testing.T) {
+	pubKey, privKey, err := secp256k1.GenKeys()
+	require.NoError(t, err)
+
+	decodedPub, err := secp256k1.BytesToPubKey(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, pubKey, decodedPub)
+	decodedPriv, err := secp256k1.BytesToPrivKey(privKey)
+	require.NoError(t, err)
+	assert.True(t, decodedPriv.Equals(privKey))
+
+	// incorrectly sized keys
+	_, err = secp256k1.BytesToPubKey(pubKey[1:])
+	assert.Error(t, err)
+	_, err = secp256k1.BytesToPubKey(append(pubKey, 0))
+	assert.Error(t, err)
+	_, err = secp256k1.BytesToPrivKey(privKey[1:])
+	assert.Error(t, err)
+	_, err = secp256k1.BytesToPrivKey(append(privKey, 0))
+	assert.Error(t, err)
+
+	// malformed public keys: unknown prefix, x with no point on the curve
+	malformed := append([]byte(nil), pubKey...)
+	malformed[0] = 0x05
+	_, err = secp256k1.BytesToPubKey(malformed)
+	assert.Error(t, err)
+	notOnCurve := make([]byte, secp256k1.PublicKeySize)
+	notOnCurve[0] = 0x02
+	notOnCurve[len(notOnCurve)-1] = 5
+	_, err = secp256k1.BytesToPubKey(notOnCurve)
+	assert.Error(t, err)
+
+	// zero and overflowing scalars
+	_, err = secp256k1.BytesToPrivKey(make([]byte, secp256k1.PrivateKeySize))
+	assert.Error(t, err)
+	overflow := make([]byte, secp256k1.PrivateKeySize)
+	for i := range overflow {
+		overflow[i] = 0xff
+	}
+	_, err = secp256k1.BytesToPrivKey(overflow)
+	assert.Error(t, err)
+	_, err = secp256k1.PrivateKey(overflow).Sign([]byte("msg"))
+	assert.Error(t, err)
+
+	sig, err := privKey.Sign([]byte("msg"))
+	require.NoError(t, err)
+	assert.False(t, secp256k1.PublicKey(malformed).VerifySignature([]byte("msg"), sig))
+	assert.False(t, secp256k1.PublicKey(notOnCurve).VerifySignature([]byte("msg"), sig))
+}
+
+func TestECDSA(t *testing.T) {
+	pubKey, privKey, err := secp256k1.GenKeys()
+	require.NoError(t, err)
+	msg := []byte("msg")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+
+	var r, s dcrsecp256k1.ModNScalar
+	require.False(t, r.SetByteSlice(sig[:32]))
+	require.False(t, s.SetByteSlice(sig[32:]))
+	assert.False(t, s.IsOverHalfOrder())
+
+	// signatures interoperate with libp2p secp256k1 identities in both directions
+	p2pPub, err := libp2pcrypto.UnmarshalSecp256k1PublicKey(pubKey)
+	require.NoError(t, err)
+	ok, err := p2pPub.Verify(msg, ecdsa.NewSignature(&r, &s).Serialize())
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	p2pPriv, err := libp2pcrypto.UnmarshalSecp256k1PrivateKey(privKey)
+	require.NoError(t, err)
+	der, err := p2pPriv.Sign(msg)
+	require.NoError(t, err)
+	p2pSig, err := ecdsa.ParseDERSignature(der)
+	require.NoError(t, err)
+	assert.Equal(t, ecdsa.NewSignature(&r, &s).Serialize(), p2pSig.Serialize())
+
+	// the high S counterpart is valid ECDSA, but malleated
+	var high dcrsecp256k1.ModNScalar
+	high.NegateVal(&s)
+	highBytes := high.Bytes()
+	malleated := append(append([]byte(nil), sig[:32]...), highBytes[:]...)
+	assert.False(t, pubKey.VerifySignature(msg, malleated))
+
+	// as well as zero and overflowing scalars
+	zero := make([]byte, secp256k1.SignatureSize)
+	assert.False(t, pubKey.VerifySignature(msg, zero))
+	overflow := append(append([]byte(nil), sig[:32]...), make([]byte, 32)...)
+	for i := 32; i < len(overflow); i++ {
+		overflow[i] = 0xff
+	}
+	assert.False(t, pubKey.VerifySignature(msg, overflow))
+}
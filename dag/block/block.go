@@ -12,9 +12,10 @@ import (
 )
 
 type Block struct {
-	blockID *blockID
-	batches [][]byte // hashes of all local batches that will be included in the block
-	parents [][]byte // hashes of the blocks from prev round
+	blockID   *blockID
+	batches   [][]byte // hashes of all local batches that will be included in the block
+	parents   [][]byte // hashes of the blocks from prev round
+	includers []byte   // hash of the includers set the block is produced for
 }
 
 func NewBlock(
@@ -22,6 +23,7 @@ func NewBlock(
 	singer []byte,
 	batches []*bapl.Batch,
 	parents [][]byte,
+	includers []byte,
 ) *Block {
 	hashes := make([][]byte, len(batches))
 	for i := range batches {
@@ -29,7 +31,7 @@ func NewBlock(
 	}
 
 	id := &blockID{round: round, signer: singer}
-	return &Block{blockID: id, batches: hashes, parents: parents}
+	return &Block{blockID: id, batches: hashes, parents: parents, includers: includers}
 }
 
 func (b *Block) ID() rebro.MessageID {
@@ -114,6 +116,11 @@ func (b *Block) MarshalBinary() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	err = block.SetIncluders(b.includers)
+	if err != nil {
+		return nil, err
+	}
 	return msg.Marshal()
 }
 
@@ -162,15 +169,25 @@ func (b *Block) UnmarshalBinary(data []byte) error {
 		parents[i] = data
 	}
 
+	b.includers, err = block.Includers()
+	if err != nil {
+		return err
+	}
+
 	b.batches = batches
 	b.parents = parents
-	return err
+	return nil
 }
 
 func (b *Block) Batches() [][]byte {
 	return b.batches
 }
 
+// IncludersHash returns the hash of the includers set the block is produced for.
+func (b *Block) IncludersHash() []byte {
+	return b.includers
+}
+
 func (b *Block) Validate() error {
 	return nil
 }
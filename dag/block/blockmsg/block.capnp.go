@@ -14,12 +14,12 @@ type Block capnp.Struct
 const Block_TypeID = 0x92df2bd885bf4d5e
 
 func NewBlock(s *capnp.Segment) (Block, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
 	return Block(st), err
 }
 
 func NewRootBlock(s *capnp.Segment) (Block, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
 	return Block(st), err
 }
 
@@ -122,13 +122,25 @@ func (s Block) NewParents(n int32) (capnp.DataList, error) {
 	err = capnp.Struct(s).SetPtr(2, l.ToPtr())
 	return l, err
 }
+func (s Block) Includers() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return []byte(p.Data()), err
+}
+
+func (s Block) HasIncluders() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s Block) SetIncluders(v []byte) error {
+	return capnp.Struct(s).SetData(3, v)
+}
 
 // Block_List is a list of Block.
 type Block_List = capnp.StructList[Block]
 
 // NewBlock creates a new list of Block.
 func NewBlock_List(s *capnp.Segment, sz int32) (Block_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
 	return capnp.StructList[Block](l), err
 }
 
@@ -238,26 +250,30 @@ func (f BlockID_Future) Struct() (BlockID, error) {
 	return BlockID(p.Struct()), err
 }
 
-const schema_ebe99359e631e3a9 = "x\xda\x84\xcf\xcdJ:Q\x18\xc7\xf1\xdf\xef\x1c_\x10" +
-	"\xfe\xfawP\xaa\x9d\xfb\xa2\xc4\x96.L\xc4 !\xc1" +
-	"Cm\x8a\x88\xc6qpD\x99\x19F\x836a\x9bn" +
-	"\xa0\xba\x83V\xad\xba\x83Zu\x0f\xed\x82\x82^\x88\x16" +
-	"\x05-\\\xc4\xc4\x09zA\x88V\xe7<_\x9e\xc5\xf3" +
-	"I_\x94#\x85\xe4\xa4\x80PS\xd1X\xb8Y?\xdf" +
-	"\xbf\x9c\xb9:\x80\x9a \xc3\x93\x9b\xc2\xed\xda\xe1\xc3#" +
-	"\xa22\x0e\x18\xcf\xd7\xc6\x9b~Gw`\xb8R:\x8e" +
-	"\xad\x97\xce\xee\xc77E\x1c\xc8<\xf1%3\xa2\xfe\xbd" +
-	"\xf2\x14\xb3a\xcbl\xe7\xfd\xc0\x1b\x08/\xdf\xecyV" +
-	"w\xce2}\xd7/Vzq\xcf\xea6H\x95\x96\x11" +
-	" B\xc00\xe7\x01\xb5!\xa9\x1cA2K\xdd\xec\"" +
-	"\xa0\xb6$UO\xd0\x10\xccR\x00F\xa7\x02\xa8\x96\xa4" +
-	"\xda\x134\xa4\xc8R\x02\xc6\xae\x8e;\x92\xeaH0\x17" +
-	"x\xdbn\x8b\x09\x08&\xc0\x85~\xa7\xed\xda\x01\x93\x10" +
-	"L\x82\xc3\xa69\xb0\x1c\xbb\xcf\x14\xd8\x90\xfc\xc8)p" +
-	"\xe8\x9b\x81\xed\x0e\xc6\xf3\xef\x80\x9cgukUM\xf8" +
-	"\xf7EX\xd4\x84\xb2\xa4Z\xfe&\xd44\xa1*\xa9\x1a" +
-	"?\x08\xf5i@-I\xaa\xd5?\xae\xfd\xef\x98}\xe7" +
-	"sx\x0f\x00\x00\xff\xff&V_\x1f"
+const schema_ebe99359e631e3a9 = "x\xda\x8c\x90\xb1\x8a\x13Q\x14\x86\xcf\x7f\xee\xec^\x04" +
+	"w\xd7\xcb\x0c\xa8U\xfa]P\xb2\xdbM\xb1\xbb\x84M" +
+	"!80G\x0d\xa8\xa08\x99\x0cIH\x9c\x84\x99\xa4" +
+	"3\xa41`a@\xf4\x114\xa0\x95O\xa0\xc6\xde\x07" +
+	"\xb0\xb3\x08\x88b\xe5\x13\x8c\xdc\x184\x8a\xe0\x16\x1f\x97" +
+	"\xff?\xdc\xcb=\xdf\xb9\xc5\xb1S\xde:\xcf\xc4ra" +
+	"c\xb3\xb8\x1b\xbc\x9b|\xdc\xfb\xf4\x94\xa4\x04\x14\xaf\x16" +
+	"\xe5\xcf\xb7\x9e}\xfdF\x1b\x8e&*\x7f\xdf\x85\x0bh" +
+	"\xcb\x01P\x02\x91\x1b\xb1.\xae\x1f>\xdf\xbc}\xf8\xf6" +
+	"\xcb\xdfwX\x13\x1d\x04\xec\xc3\xbd\xc3z\xc5k\"\xf7" +
+	"\xa2\xd2\xb4W4\xa2\xe6\xe5z\xb7\x17\xab\xce\xf2\xe8\xdc" +
+	"\xcf\x7f\xe6\xce\xa58\xea\xa7}\xbf\xb2cC\x08\x84`" +
+	"\xf1\x94C\xe4\x80\xc8\x8c\xf6\xcdH\xcb\x03\x05y\xc4\x00" +
+	"<\xd8r\xe2\x9b\x89\x96\x87\x0a\xf2\x84a\x18\x1e\x98\xc8" +
+	"L+f\xaa\xe5\xb1\x82\xbcd\x18\xc5\x1e\x14\x91\x99U" +
+	"\xccL\xcb\x0b\x05\x993\x8c\xa3<8D\xe6\xcd5\xf3" +
+	"^\xcb\\A>0JYo\x986B0\xce\x90\x05" +
+	"Gy\xbb\x99&\x99m\xb6\xc8\x82q=\x1a\xc4\xad$" +
+	"\xb7\xd56!TXN\xb6\x09\xe3~\x94%\xe9\xe0\x1f" +
+	"\x93\xa2\x9d\xc6\xdda#\xc9\x08\xf9\xdaS\xc7\xf8\xbf\x8b" +
+	"#\x1b\xae\x9c\xacl\x9c\xfde\xa3\xbao\xaaZN\x14" +
+	"$\xfcm#\xf0M\xa0\xe5\xaa\x82\xdc\\\xb3Q\xdb5" +
+	"5-7\x14\xe4\xde\xa96\xdciEy\xeb\x8fo\xfe" +
+	"\x18\x00\xfc&t\x9d"
 
 func RegisterSchema(reg *schemas.Registry) {
 	reg.Register(&schemas.Schema{
@@ -1,6 +1,7 @@
 package dag
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -12,12 +13,15 @@ import (
 )
 
 type certifier struct {
-	pool bapl.BatchPool
-	log  *slog.Logger
+	pool      bapl.BatchPool
+	includers IncludersFn
+	log       *slog.Logger
 }
 
-func NewCertifier(pool bapl.BatchPool) rebro.Certifier {
-	return &certifier{pool: pool, log: slog.With("module", "certifiers")}
+// NewCertifier instantiates a new [rebro.Certifier] of blocks ensuring availability of their
+// batches and that blocks are produced for the same includers set.
+func NewCertifier(pool bapl.BatchPool, includers IncludersFn) rebro.Certifier {
+	return &certifier{pool: pool, includers: includers, log: slog.With("module", "certifiers")}
 }
 
 func (c *certifier) Certify(ctx context.Context, msg rebro.Message) error {
@@ -40,6 +44,18 @@ func (c *certifier) Certify(ctx context.Context, msg rebro.Message) error {
 		return fmt.Errorf("validating block %w", err)
 	}
 
+	includers, err := c.includers(blk.Round())
+	if err != nil {
+		return fmt.Errorf("getting includers: %w", err)
+	}
+	includersHash, err := includers.Hash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(blk.IncludersHash(), includersHash) {
+		return fmt.Errorf("block includers(%X) diverge from local includers(%X)", blk.IncludersHash(), includersHash)
+	}
+
 	for _, hash := range blk.Batches() {
 		_, err = c.pool.Pull(ctx, hash)
 		if err != nil && !errors.Is(err, bapl.ErrBatchDeleted) { // TODO: This is a temporary workaround
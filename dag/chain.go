@@ -2,6 +2,8 @@ package dag
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -15,15 +17,28 @@ import (
 
 type IncludersFn func(round uint64) (*quorum.Includers, error)
 
+// RoundFn is notified with certificates of every finished round.
+type RoundFn func(round uint64, certs []rebro.Certificate)
+
+// interruptedRound keeps the state of the interrupted round to resume it.
+type interruptedRound struct {
+	msg rebro.Message
+	qrm *quorum.Quorum
+}
+
 // Chain produces everlasting DAG chain of blocks broadcasting them over reliable broadcast.
 type Chain struct {
 	broadcaster rebro.Broadcaster
 	batchPool   bapl.BatchPool
 	includers   IncludersFn
 	signerID    crypto.PubKey
+	onRound     RoundFn
+	quorumOpts  []quorum.Option
 
 	height    uint64
 	lastCerts []rebro.Certificate
+	// interrupted round to be resumed
+	interrupted *interruptedRound
 
 	log    *slog.Logger
 	cancel context.CancelFunc
@@ -34,17 +49,25 @@ func NewChain(
 	pool bapl.BatchPool,
 	includers IncludersFn,
 	signerID crypto.PubKey,
+	quorumOpts ...quorum.Option,
 ) *Chain {
 	return &Chain{
 		broadcaster: broadcaster,
 		batchPool:   pool,
 		includers:   includers,
 		signerID:    signerID,
+		quorumOpts:  quorumOpts,
 		height:      1, // must start from 1
 		log:         slog.With("module", "dagger"),
 	}
 }
 
+// OnRound sets the RoundFn notified with certificates of every finished round.
+// It must be set before the Chain is started.
+func (c *Chain) OnRound(fn RoundFn) {
+	c.onRound = fn
+}
+
 func (c *Chain) Start() {
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
@@ -77,7 +100,45 @@ func (c *Chain) run(ctx context.Context) {
 // * prepare the new uncommitted batches
 // * create a block from the batches and the parents hashes;
 // * propagate the block and wait until quorum is reached;
+//
+// If broadcasting gets interrupted, the next attempt resumes the round with the same block.
 func (c *Chain) startRound(ctx context.Context) error {
+	msg, qrm, err := c.prepareRound(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = c.broadcast(ctx, msg, qrm)
+	if err != nil {
+		if errors.Is(err, rebro.ErrRoundInterrupted) {
+			// keep the round to resume it with the same block and partial quorum
+			c.interrupted = &interruptedRound{msg: msg, qrm: qrm}
+		}
+		return err
+	}
+	c.log.InfoContext(ctx, "finished round",
+		"height", c.height,
+		"certificates", len(qrm.List()),
+		"time", time.Since(now),
+	)
+
+	c.interrupted = nil
+	c.lastCerts = qrm.List()
+	if c.onRound != nil {
+		c.onRound(c.height, c.lastCerts)
+	}
+	c.height++
+	return nil
+}
+
+// prepareRound assembles a new block for the round or takes the one of the interrupted round.
+func (c *Chain) prepareRound(ctx context.Context) (rebro.Message, *quorum.Quorum, error) {
+	if c.interrupted != nil {
+		c.log.InfoContext(ctx, "resuming interrupted round", "height", c.height)
+		return c.interrupted.msg, c.interrupted.qrm, nil
+	}
+
 	parents := make([][]byte, len(c.lastCerts))
 	for i, cert := range c.lastCerts {
 		parents[i] = cert.Message().ID.Hash()
@@ -98,37 +159,71 @@ func (c *Chain) startRound(ctx context.Context) error {
 
 	newBatches, err := c.batchPool.ListBySigner(ctx, c.signerID.Bytes())
 	if err != nil {
-		return fmt.Errorf("can't get batches for the new height:%w", err)
+		return rebro.Message{}, nil, fmt.Errorf("can't get batches for the new height:%w", err)
+	}
+
+	includers, err := c.includers(c.height)
+	if err != nil {
+		return rebro.Message{}, nil, err
+	}
+	includersHash, err := includers.Hash()
+	if err != nil {
+		return rebro.Message{}, nil, err
 	}
 
 	// TODO: certificate signatures should be the part of the block.
-	blk := block.NewBlock(c.height, c.signerID.Bytes(), newBatches, parents)
+	blk := block.NewBlock(c.height, c.signerID.Bytes(), newBatches, parents, includersHash)
 	blk.Hash() // TODO: Compute in constructor
 	data, err := blk.MarshalBinary()
 	if err != nil {
-		return err
+		return rebro.Message{}, nil, err
 	}
 
-	includers, err := c.includers(c.height)
+	c.log.DebugContext(ctx, "assembled block",
+		"height", c.height,
+		"batches", len(newBatches),
+		"parents", len(parents),
+	)
+	return rebro.Message{ID: blk.ID(), Data: data}, quorum.NewQuorum(includers, c.quorumOpts...), nil
+}
+
+// broadcast broadcasts the message and awaits finalization, logging partial progress if the
+// broadcaster allows tracking it.
+func (c *Chain) broadcast(ctx context.Context, msg rebro.Message, qrm rebro.QuorumCertificate) error {
+	async, ok := c.broadcaster.(rebro.AsyncBroadcaster)
+	if !ok {
+		return c.broadcaster.Broadcast(ctx, msg, qrm)
+	}
+
+	h, err := async.BroadcastAsync(ctx, msg, qrm)
 	if err != nil {
 		return err
 	}
 
 	now := time.Now()
-	msg := rebro.Message{ID: blk.ID(), Data: data}
-	qrm := quorum.NewQuorum(includers)
-	err = c.broadcaster.Broadcast(ctx, msg, qrm)
-	if err != nil {
-		return err
+	for ev := range h.Events() {
+		switch ev.Kind {
+		case rebro.EventCertificateAdded:
+			c.log.DebugContext(ctx, "received block",
+				"height", c.height,
+				"signer", hex.EncodeToString(ev.ID.Signer()),
+				"time", time.Since(now),
+			)
+		case rebro.EventCertified:
+			if ev.ID.String() == msg.ID.String() {
+				c.log.DebugContext(ctx, "certified own block", "height", c.height, "time", time.Since(now))
+				continue
+			}
+			c.log.DebugContext(ctx, "certified block",
+				"height", c.height,
+				"signer", hex.EncodeToString(ev.ID.Signer()),
+				"time", time.Since(now),
+			)
+		default:
+		}
 	}
-	c.log.InfoContext(ctx, "finished round",
-		"height", c.height,
-		"batches", len(newBatches),
-		"parents", len(parents),
-		"time", time.Since(now),
-	)
 
-	c.lastCerts = qrm.List()
-	c.height++
-	return nil
+	// broadcasting has finished once events are drained, so the outcome is awaited regardless of
+	// the context, which may have been canceled in the meantime hiding the round interruption
+	return h.AwaitFinalized(context.WithoutCancel(ctx))
 }
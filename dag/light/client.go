@@ -0,0 +1,248 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"capnproto.org/go/capnp/v3"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/dag"
+	"github.com/iykyk-syn/unison/dag/block"
+	"github.com/iykyk-syn/unison/dag/light/lightmsg"
+	"github.com/iykyk-syn/unison/dag/quorum"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// ErrRoundNotSynced is returned for rounds the Client hasn't synced yet.
+var ErrRoundNotSynced = errors.New("round is not synced")
+
+// Client follows the DAG chain by fetching and verifying headers of every round from full nodes.
+type Client struct {
+	networkID  rebro.NetworkID
+	host       host.Host
+	protocolID protocol.ID
+	includers  dag.IncludersFn
+	verifier   crypto.Verifier
+	decoder    rebro.MessageIDDecoder
+	hasher     rebro.Hasher
+	quorumOpts []quorum.Option
+
+	roundsMu sync.RWMutex
+	height   uint64
+	rounds   map[uint64][]*Header
+
+	log *slog.Logger
+}
+
+// NewClient instantiates a new Client tracking the includers set of every round.
+// The verifier must verify signatures within the domain they are produced in by the broadcaster,
+// e.g. [gossip.SignatureDomain]. Quorum options must match the ones of the network.
+func NewClient(
+	networkID rebro.NetworkID,
+	host host.Host,
+	includers dag.IncludersFn,
+	verifier crypto.Verifier,
+	decoder rebro.MessageIDDecoder,
+	quorumOpts ...quorum.Option,
+) *Client {
+	return &Client{
+		networkID:  networkID,
+		host:       host,
+		protocolID: ProtocolID(networkID),
+		includers:  includers,
+		verifier:   verifier,
+		decoder:    decoder,
+		hasher:     dag.NewHasher(),
+		quorumOpts: quorumOpts,
+		rounds:     make(map[uint64][]*Header),
+		log:        slog.With("module", "light-client"),
+	}
+}
+
+// Height returns the latest synced round.
+func (c *Client) Height() uint64 {
+	c.roundsMu.RLock()
+	defer c.roundsMu.RUnlock()
+	return c.height
+}
+
+// Sync fetches and verifies every round after the synced one up until the given round.
+func (c *Client) Sync(ctx context.Context, from peer.ID, round uint64) error {
+	for next := c.Height() + 1; next <= round; next++ {
+		headers, err := c.Fetch(ctx, from, next)
+		if err != nil {
+			return fmt.Errorf("syncing round %d: %w", next, err)
+		}
+
+		c.roundsMu.Lock()
+		c.rounds[next] = headers
+		c.height = next
+		c.roundsMu.Unlock()
+		c.log.DebugContext(ctx, "synced round", "round", next, "headers", len(headers))
+	}
+	return nil
+}
+
+// Headers returns verified headers of the synced round.
+func (c *Client) Headers(round uint64) ([]*Header, error) {
+	c.roundsMu.RLock()
+	defer c.roundsMu.RUnlock()
+
+	headers, ok := c.rounds[round]
+	if !ok {
+		return nil, ErrRoundNotSynced
+	}
+	return headers, nil
+}
+
+// VerifyInclusion verifies the batch is included in a certified block of the synced round and
+// returns the MessageID of the block.
+func (c *Client) VerifyInclusion(round uint64, batchHash []byte) (rebro.MessageID, error) {
+	headers, err := c.Headers(round)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range headers {
+		ok, err := h.Includes(batchHash)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return h.Certificate.ID, nil
+		}
+	}
+	return nil, fmt.Errorf("batch(%X) is not included in round %d", batchHash, round)
+}
+
+// Fetch requests headers of the round from the peer and verifies them.
+func (c *Client) Fetch(ctx context.Context, from peer.ID, round uint64) ([]*Header, error) {
+	headers, err := c.request(ctx, from, round)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.verifyRound(round, headers); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// verifyRound verifies every header of the round and that their producers have enough weight
+// for the round to be finalized.
+func (c *Client) verifyRound(round uint64, headers []*Header) error {
+	includers, err := c.includers(round)
+	if err != nil {
+		return err
+	}
+
+	qrm := quorum.NewQuorum(includers, c.quorumOpts...)
+	for _, h := range headers {
+		if err := c.verifyHeader(round, includers, h); err != nil {
+			return fmt.Errorf("verifying header(%s): %w", h.Certificate.ID.String(), err)
+		}
+
+		msg := rebro.Message{ID: h.Certificate.ID, Data: h.Block}
+		if err := qrm.Add(msg); err != nil {
+			return fmt.Errorf("adding header(%s): %w", h.Certificate.ID.String(), err)
+		}
+		cert, _ := qrm.Get(msg.ID)
+		for _, sig := range h.Certificate.Signatures {
+			if _, err := cert.AddSignature(sig); err != nil {
+				return err
+			}
+		}
+	}
+
+	finalized, err := qrm.Finalize()
+	if err != nil {
+		return err
+	}
+	if !finalized {
+		return errors.New("certified blocks do not have enough weight to finalize the round")
+	}
+	return nil
+}
+
+func (c *Client) verifyHeader(round uint64, includers *quorum.Includers, h *Header) error {
+	cert := h.Certificate
+	if cert.ID.Round() != round {
+		return fmt.Errorf("certificate of round %d", cert.ID.Round())
+	}
+	if err := quorum.Verify(cert, c.networkID, includers, c.verifier, c.quorumOpts...); err != nil {
+		return err
+	}
+
+	hash, err := c.hasher.Hash(rebro.Message{ID: cert.ID, Data: h.Block})
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, cert.Hash) {
+		return errors.New("block does not match the certificate hash")
+	}
+
+	var blk block.Block
+	if err = blk.UnmarshalBinary(h.Block); err != nil {
+		return fmt.Errorf("unmarshalling block: %w", err)
+	}
+	if blk.Round() != round || !bytes.Equal(blk.ID().Signer(), cert.ID.Signer()) {
+		return errors.New("block does not match the certificate")
+	}
+	includersHash, err := includers.Hash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(blk.IncludersHash(), includersHash) {
+		return errors.New("block is produced for other includers")
+	}
+	return nil
+}
+
+func (c *Client) request(ctx context.Context, from peer.ID, round uint64) ([]*Header, error) {
+	stream, err := c.host.NewStream(ctx, from, c.protocolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer stream.Close()
+
+	if dl, ok := ctx.Deadline(); ok {
+		if err = stream.SetDeadline(dl); err != nil {
+			c.log.WarnContext(ctx, "error setting deadline", "err", err)
+		}
+	}
+
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	req, err := lightmsg.NewRootRequest(seg)
+	if err != nil {
+		return nil, err
+	}
+	req.SetRound(round)
+	data, err := msg.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = stream.Write(data); err != nil {
+		return nil, fmt.Errorf("writing request: %w", err)
+	}
+	if err = stream.CloseWrite(); err != nil {
+		return nil, err
+	}
+
+	data, err = io.ReadAll(io.LimitReader(stream, maxMessageSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return unmarshalResponse(data, c.decoder)
+}
@@ -0,0 +1,120 @@
+// Package light implements a light client following the DAG chain without downloading batches.
+//
+// Full nodes serve certified blocks of every round together with their certificates through
+// the [Server], while the [Client] fetches them round by round, verifying every certificate and
+// the round itself against the includers set by the quorum policy, 2f+1 stake by default.
+// As blocks only carry hashes of batches, verified blocks are enough to prove inclusion of a batch
+// in the chain.
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"capnproto.org/go/capnp/v3"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/iykyk-syn/unison/dag/block"
+	"github.com/iykyk-syn/unison/dag/light/lightmsg"
+	"github.com/iykyk-syn/unison/dag/quorum"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// maxMessageSize limits the size of the protocol messages read from the streams.
+const maxMessageSize = 64 << 20
+
+// ProtocolID returns the libp2p protocol headers of the network are served over.
+func ProtocolID(networkID rebro.NetworkID) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s/light/v0.0.1", networkID))
+}
+
+// Header is a certified block of a round.
+type Header struct {
+	// Certificate attests to the block.
+	Certificate *quorum.Certificate
+	// Block is the serialized block.
+	Block []byte
+}
+
+// Includes reports whether the block of the Header includes the batch.
+// The Header is expected to be verified.
+func (h *Header) Includes(batchHash []byte) (bool, error) {
+	var blk block.Block
+	if err := blk.UnmarshalBinary(h.Block); err != nil {
+		return false, fmt.Errorf("unmarshalling block: %w", err)
+	}
+
+	for _, hash := range blk.Batches() {
+		if bytes.Equal(hash, batchHash) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func marshalResponse(headers []*Header) ([]byte, error) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lightmsg.NewRootResponse(seg)
+	if err != nil {
+		return nil, err
+	}
+	list, err := resp.NewHeaders(int32(len(headers)))
+	if err != nil {
+		return nil, err
+	}
+	for i, h := range headers {
+		cert, err := h.Certificate.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if err = list.At(i).SetCertificate(cert); err != nil {
+			return nil, err
+		}
+		if err = list.At(i).SetBlock(h.Block); err != nil {
+			return nil, err
+		}
+	}
+	return msg.Marshal()
+}
+
+func unmarshalResponse(data []byte, decoder rebro.MessageIDDecoder) ([]*Header, error) {
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lightmsg.ReadRootResponse(msg)
+	if err != nil {
+		return nil, err
+	}
+	list, err := resp.Headers()
+	if err != nil {
+		return nil, err
+	}
+	if list.Len() == 0 {
+		return nil, errors.New("round is not available")
+	}
+
+	headers := make([]*Header, list.Len())
+	for i := range list.Len() {
+		data, err := list.At(i).Certificate()
+		if err != nil {
+			return nil, err
+		}
+		cert, err := quorum.UnmarshalCertificate(data, decoder)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshalling certificate: %w", err)
+		}
+		blk, err := list.At(i).Block()
+		if err != nil {
+			return nil, err
+		}
+		headers[i] = &Header{Certificate: cert, Block: blk}
+	}
+	return headers, nil
+}
@@ -0,0 +1,159 @@
+package light
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/bapl"
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/crypto/local"
+	"github.com/iykyk-syn/unison/dag/block"
+	"github.com/iykyk-syn/unison/dag/quorum"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+const (
+	testNetworkID = rebro.NetworkID("test")
+	includerCount = 4
+	roundCount    = 3
+)
+
+func TestLightClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	full, lightHost := net.Hosts()[0], net.Hosts()[1]
+
+	privKeys, includers := newIncluders(t)
+	includersFn := func(uint64) (*quorum.Includers, error) { return includers, nil }
+
+	server := NewServer(testNetworkID, full)
+	server.Start()
+	t.Cleanup(server.Stop)
+
+	batch := &bapl.Batch{Data: []byte("batch")}
+	for round := uint64(1); round <= roundCount; round++ {
+		server.Add(round, certifyRound(t, round, privKeys, includers, batch))
+	}
+
+	client := NewClient(testNetworkID, lightHost, includersFn, local.NewVerifier(), block.UnmarshalBlockID)
+	require.NoError(t, client.Sync(ctx, full.ID(), roundCount))
+	assert.EqualValues(t, roundCount, client.Height())
+
+	headers, err := client.Headers(roundCount)
+	require.NoError(t, err)
+	assert.Len(t, headers, includerCount-1)
+
+	id, err := client.VerifyInclusion(2, batch.Hash())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, id.Round())
+	_, err = client.VerifyInclusion(2, (&bapl.Batch{Data: []byte("other")}).Hash())
+	assert.Error(t, err)
+	_, err = client.VerifyInclusion(roundCount+1, batch.Hash())
+	assert.ErrorIs(t, err, ErrRoundNotSynced)
+
+	// rounds not served can't be synced
+	err = client.Sync(ctx, full.ID(), roundCount+1)
+	assert.Error(t, err)
+	assert.EqualValues(t, roundCount, client.Height())
+
+	// rounds without enough certified blocks can't be synced
+	certs := certifyRound(t, roundCount+1, privKeys, includers, batch)
+	server.Add(roundCount+1, certs[:1])
+	err = client.Sync(ctx, full.ID(), roundCount+1)
+	assert.Error(t, err)
+
+	// rounds certified by other includers can't be synced
+	otherKeys, otherIncluders := newIncluders(t)
+	server.Add(roundCount+1, certifyRound(t, roundCount+1, otherKeys, otherIncluders, batch))
+	err = client.Sync(ctx, full.ID(), roundCount+1)
+	assert.Error(t, err)
+
+	server.Add(roundCount+1, certs)
+	require.NoError(t, client.Sync(ctx, full.ID(), roundCount+1))
+	assert.EqualValues(t, roundCount+1, client.Height())
+}
+
+func TestServerRetainedRounds(t *testing.T) {
+	net, err := mocknet.FullMeshConnected(1)
+	require.NoError(t, err)
+	server := NewServer(testNetworkID, net.Hosts()[0], WithRetainedRounds(2))
+
+	privKeys, includers := newIncluders(t)
+	batch := &bapl.Batch{Data: []byte("batch")}
+	certs := certifyRound(t, 1, privKeys, includers, batch)
+	for round := uint64(1); round <= roundCount; round++ {
+		server.Add(round, certs)
+	}
+	assert.Len(t, server.rounds, 2)
+	assert.NotContains(t, server.rounds, uint64(1))
+
+	// rounds out of the window are not kept
+	server.Add(1, certs)
+	assert.NotContains(t, server.rounds, uint64(1))
+	// rounds within the window are still updated
+	server.Add(roundCount-1, certs[:1])
+	assert.Len(t, server.rounds[roundCount-1], 1)
+
+	// gaps evict all the rounds behind the window
+	server.Add(roundCount+5, certs)
+	assert.Len(t, server.rounds, 1)
+	assert.Contains(t, server.rounds, uint64(roundCount+5))
+}
+
+// certifyRound produces blocks of all but one includers certified by the same includers.
+func certifyRound(
+	t *testing.T,
+	round uint64,
+	privKeys []ed25519.PrivateKey,
+	includers *quorum.Includers,
+	batch *bapl.Batch,
+) []rebro.Certificate {
+	includersHash, err := includers.Hash()
+	require.NoError(t, err)
+
+	qrm := quorum.NewQuorum(includers)
+	for _, privKey := range privKeys[1:] {
+		blk := block.NewBlock(round, privKey.PubKey().Bytes(), []*bapl.Batch{batch}, nil, includersHash)
+		blk.Hash()
+		data, err := blk.MarshalBinary()
+		require.NoError(t, err)
+		msg := rebro.Message{ID: blk.ID(), Data: data}
+		require.NoError(t, qrm.Add(msg))
+
+		canonicalID, err := msg.ID.MarshalBinary()
+		require.NoError(t, err)
+		cert, _ := qrm.Get(msg.ID)
+		for _, signer := range privKeys[1:] {
+			body, err := signer.Sign(canonicalID)
+			require.NoError(t, err)
+			_, err = cert.AddSignature(crypto.Signature{Body: body, Signer: signer.PubKey().Bytes()})
+			require.NoError(t, err)
+		}
+	}
+
+	finalized, err := qrm.Finalize()
+	require.NoError(t, err)
+	require.True(t, finalized)
+	return qrm.List()
+}
+
+func newIncluders(t *testing.T) ([]ed25519.PrivateKey, *quorum.Includers) {
+	privKeys := make([]ed25519.PrivateKey, includerCount)
+	incls := make([]*quorum.Includer, includerCount)
+	for i := range includerCount {
+		pubKey, privKey, err := ed25519.GenKeys()
+		require.NoError(t, err)
+		privKeys[i] = privKey
+		incls[i] = quorum.NewIncluder(pubKey, 1)
+	}
+	return privKeys, quorum.NewIncludersSet(incls)
+}
@@ -0,0 +1,294 @@
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package lightmsg
+
+import (
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/encoding/text"
+	"capnproto.org/go/capnp/v3/schemas"
+)
+
+type Request capnp.Struct
+
+// Request_TypeID is the unique identifier for the type Request.
+const Request_TypeID = 0xfb3c1428bef0fb91
+
+func NewRequest(s *capnp.Segment) (Request, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Request(st), err
+}
+
+func NewRootRequest(s *capnp.Segment) (Request, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Request(st), err
+}
+
+func ReadRootRequest(msg *capnp.Message) (Request, error) {
+	root, err := msg.Root()
+	return Request(root.Struct()), err
+}
+
+func (s Request) String() string {
+	str, _ := text.Marshal(0xfb3c1428bef0fb91, capnp.Struct(s))
+	return str
+}
+
+func (s Request) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Request) DecodeFromPtr(p capnp.Ptr) Request {
+	return Request(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Request) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Request) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Request) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Request) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Request) Round() uint64 {
+	return capnp.Struct(s).Uint64(0)
+}
+
+func (s Request) SetRound(v uint64) {
+	capnp.Struct(s).SetUint64(0, v)
+}
+
+// Request_List is a list of Request.
+type Request_List = capnp.StructList[Request]
+
+// NewRequest creates a new list of Request.
+func NewRequest_List(s *capnp.Segment, sz int32) (Request_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return capnp.StructList[Request](l), err
+}
+
+// Request_Future is a wrapper for a Request promised by a client call.
+type Request_Future struct{ *capnp.Future }
+
+func (f Request_Future) Struct() (Request, error) {
+	p, err := f.Future.Ptr()
+	return Request(p.Struct()), err
+}
+
+type Response capnp.Struct
+
+// Response_TypeID is the unique identifier for the type Response.
+const Response_TypeID = 0xbb96fa1271e2e125
+
+func NewResponse(s *capnp.Segment) (Response, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Response(st), err
+}
+
+func NewRootResponse(s *capnp.Segment) (Response, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Response(st), err
+}
+
+func ReadRootResponse(msg *capnp.Message) (Response, error) {
+	root, err := msg.Root()
+	return Response(root.Struct()), err
+}
+
+func (s Response) String() string {
+	str, _ := text.Marshal(0xbb96fa1271e2e125, capnp.Struct(s))
+	return str
+}
+
+func (s Response) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Response) DecodeFromPtr(p capnp.Ptr) Response {
+	return Response(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Response) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Response) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Response) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Response) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Response) Headers() (Header_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Header_List(p.List()), err
+}
+
+func (s Response) HasHeaders() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s Response) SetHeaders(v Header_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewHeaders sets the headers field to a newly
+// allocated Header_List, preferring placement in s's segment.
+func (s Response) NewHeaders(n int32) (Header_List, error) {
+	l, err := NewHeader_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Header_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// Response_List is a list of Response.
+type Response_List = capnp.StructList[Response]
+
+// NewResponse creates a new list of Response.
+func NewResponse_List(s *capnp.Segment, sz int32) (Response_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[Response](l), err
+}
+
+// Response_Future is a wrapper for a Response promised by a client call.
+type Response_Future struct{ *capnp.Future }
+
+func (f Response_Future) Struct() (Response, error) {
+	p, err := f.Future.Ptr()
+	return Response(p.Struct()), err
+}
+
+type Header capnp.Struct
+
+// Header_TypeID is the unique identifier for the type Header.
+const Header_TypeID = 0x86ff6344a39d1b54
+
+func NewHeader(s *capnp.Segment) (Header, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Header(st), err
+}
+
+func NewRootHeader(s *capnp.Segment) (Header, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Header(st), err
+}
+
+func ReadRootHeader(msg *capnp.Message) (Header, error) {
+	root, err := msg.Root()
+	return Header(root.Struct()), err
+}
+
+func (s Header) String() string {
+	str, _ := text.Marshal(0x86ff6344a39d1b54, capnp.Struct(s))
+	return str
+}
+
+func (s Header) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Header) DecodeFromPtr(p capnp.Ptr) Header {
+	return Header(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Header) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Header) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Header) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Header) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Header) Certificate() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s Header) HasCertificate() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s Header) SetCertificate(v []byte) error {
+	return capnp.Struct(s).SetData(0, v)
+}
+
+func (s Header) Block() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s Header) HasBlock() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s Header) SetBlock(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+// Header_List is a list of Header.
+type Header_List = capnp.StructList[Header]
+
+// NewHeader creates a new list of Header.
+func NewHeader_List(s *capnp.Segment, sz int32) (Header_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[Header](l), err
+}
+
+// Header_Future is a wrapper for a Header promised by a client call.
+type Header_Future struct{ *capnp.Future }
+
+func (f Header_Future) Struct() (Header, error) {
+	p, err := f.Future.Ptr()
+	return Header(p.Struct()), err
+}
+
+const schema_de9092069765f7a5 = "x\xdat\x901K#A\x18\x86\xbfwv\x93\xb9\x83" +
+	"\xdb\x90\xbdMqpwlup\xc7]\xee\xceh@" +
+	"\x82\x90%XX\xee\x04\xff\xc0f3&\xc1\x98M\xb2" +
+	"\x1b,\xed\xac\xd5B\xb1\xb0\x12\x0b\x0bK+\xc1\x9f\"" +
+	"hgg'\x1ade\xe2\x1a\xa3\x92\xe2e\x98\x0f\x9e" +
+	"y\x9f\xf9\xb2\xa7\x8e>c\x04\x8c\x98\xf8\x96J\xc7\xcb" +
+	"_\x0f\x0e\x17\xfdx\x93L\x1b\xf1\xd1\xad\xdcK\xefl" +
+	"]P\x8aq\xa2\xd9\xef(\xc0\xca\x83'Y'\xb2N" +
+	"\xc0\xe3\x1f\x97W\xbd\xcf\xf7\xbbgo\x10(d\x1f\x15" +
+	"X\xc7\xe0I\xcaD\xd6\x03x\xbc=\xbc9\xff\x99[" +
+	"\x18\x92\xb01\xc1\xe8\x0a\xb9F\x09\xd6\x1dx\x12\x85\x14" +
+	"\x19\xa7\xdfq\xddk\xfck\xb7\x1aM-\x1a\x1d\xd1Z" +
+	"\xf8t\x8f\xfe\xfa^\xb7\xd3--\xd9\xd2\xab\xcb\xbe\x0b" +
+	"\xb8`\xe2\x83\xa6\x13\xe9 2\x7f\xd5\xcc<\x17\x7f4" +
+	"\x88y\x06\x13\xc8AM\x8b\x05\xb3\xc8\xc5\x9c\x06\xe10" +
+	"\xc4\xbe\xecG\xad\x95\x96O\xdc\x8b\xa4\x0b\x06\x83T`" +
+	"\xd7\xda\x81\xbf:1p0\xf6\xd0\xa7xTe\xd8\x0d" +
+	":\xa1\xa4\xc4D\x1f\x9b\x18\x15\xd3\xe0\xe2\x93\x06\xf1\x9f" +
+	"a\xa39\xd2\x0d\xd5\xe3\x19\x82\xab\x01\xd9\x97\xf5\x139" +
+	"0\xc1]\x06d^\xb5N\xfb}\xb5,{\x03\x19F" +
+	"\xefK\x0b\xcf\xa5_\x18\xec~0\xe8\xd4U\xe5GR" +
+	"\x81\x83\xc7\x01\x00\x8d\xeb\x7fs"
+
+func RegisterSchema(reg *schemas.Registry) {
+	reg.Register(&schemas.Schema{
+		String: schema_de9092069765f7a5,
+		Nodes: []uint64{
+			0x86ff6344a39d1b54,
+			0xbb96fa1271e2e125,
+			0xfb3c1428bef0fb91,
+		},
+		Compressed: true,
+	})
+}
@@ -0,0 +1,143 @@
+package light
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/iykyk-syn/unison/dag/light/lightmsg"
+	"github.com/iykyk-syn/unison/dag/quorum"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+const (
+	// serveTimeout bounds the time a request is served in.
+	serveTimeout = time.Second * 30
+	// DefaultRetainedRounds is the default number of the latest rounds served.
+	DefaultRetainedRounds = 1024
+)
+
+// Server serves headers of the rounds produced by a full node to light clients.
+type Server struct {
+	networkID  rebro.NetworkID
+	host       host.Host
+	protocolID protocol.ID
+
+	// retained is the number of the latest rounds kept to be served
+	retained uint64
+	roundsMu sync.RWMutex
+	latest   uint64
+	rounds   map[uint64][]*Header
+
+	log *slog.Logger
+}
+
+// ServerOption configures the [Server].
+type ServerOption func(*Server)
+
+// WithRetainedRounds sets the number of the latest rounds the [Server] keeps to serve.
+// Older rounds are evicted as new ones are added.
+func WithRetainedRounds(rounds uint64) ServerOption {
+	return func(s *Server) {
+		s.retained = max(rounds, 1)
+	}
+}
+
+func NewServer(networkID rebro.NetworkID, host host.Host, opts ...ServerOption) *Server {
+	s := &Server{
+		networkID:  networkID,
+		host:       host,
+		protocolID: ProtocolID(networkID),
+		retained:   DefaultRetainedRounds,
+		rounds:     make(map[uint64][]*Header),
+		log:        slog.With("module", "light-server"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Server) Start() {
+	s.host.SetStreamHandler(s.protocolID, func(stream network.Stream) {
+		if err := s.serve(stream); err != nil {
+			s.log.Error("serving request", "err", err)
+			stream.Reset() //nolint: errcheck
+		}
+	})
+	s.log.Debug("started")
+}
+
+func (s *Server) Stop() {
+	s.host.RemoveStreamHandler(s.protocolID)
+}
+
+// Add keeps certificates of the finished round to be served, evicting rounds which fall out of
+// the retained window. Rounds already out of the window are ignored.
+// It matches [dag.RoundFn] to be plugged into the chain.
+func (s *Server) Add(round uint64, certs []rebro.Certificate) {
+	headers := make([]*Header, len(certs))
+	for i, cert := range certs {
+		headers[i] = &Header{
+			Certificate: quorum.NewCertificate(s.networkID, cert),
+			Block:       cert.Message().Data,
+		}
+	}
+
+	s.roundsMu.Lock()
+	defer s.roundsMu.Unlock()
+
+	if round > s.latest {
+		s.latest = round
+	}
+	if s.latest-round >= s.retained {
+		return
+	}
+	s.rounds[round] = headers
+
+	for r := range s.rounds {
+		if s.latest-r >= s.retained {
+			delete(s.rounds, r)
+		}
+	}
+}
+
+func (s *Server) serve(stream network.Stream) error {
+	if err := stream.SetDeadline(time.Now().Add(serveTimeout)); err != nil {
+		s.log.Warn("error setting deadline", "err", err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(stream, maxMessageSize))
+	if err != nil {
+		return fmt.Errorf("reading request: %w", err)
+	}
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	req, err := lightmsg.ReadRootRequest(msg)
+	if err != nil {
+		return err
+	}
+
+	s.roundsMu.RLock()
+	headers := s.rounds[req.Round()]
+	s.roundsMu.RUnlock()
+
+	// unknown rounds are responded with no headers
+	resp, err := marshalResponse(headers)
+	if err != nil {
+		return err
+	}
+	if _, err = stream.Write(resp); err != nil {
+		return fmt.Errorf("writing response: %w", err)
+	}
+	return stream.Close()
+}
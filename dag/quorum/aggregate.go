@@ -0,0 +1,152 @@
+package quorum
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/bls"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// AggregateSignature is a single BLS signature aggregating signatures of a certificate together
+// with the bitmap of includers who produced them.
+type AggregateSignature struct {
+	// Signature aggregates signatures of all the signers.
+	Signature []byte
+	// Signers is the bitmap of signers, where bit i is set if the includer with index i
+	// in the set has signed.
+	Signers []byte
+}
+
+// Verify verifies the AggregateSignature over the canonical MessageID signed within the domain
+// and checks its signers have enough weight to complete a certificate by the [Policy].
+func (a *AggregateSignature) Verify(
+	includers *Includers,
+	domain crypto.Domain,
+	id rebro.MessageID,
+	opts ...Option,
+) error {
+	policy := newOptions(opts).policy
+
+	if len(a.Signers) != bitmapSize(includers.Len()) {
+		return fmt.Errorf("signers bitmap of %d bytes for %d includers", len(a.Signers), includers.Len())
+	}
+
+	var weight int64
+	pubKeys := make([]bls.PublicKey, 0, includers.Len())
+	for idx := range len(a.Signers) * 8 {
+		if !bitmapHas(a.Signers, idx) {
+			continue
+		}
+
+		includer := includers.GetByIndex(idx)
+		if includer == nil {
+			return fmt.Errorf("signer #%d is not a part of includers set", idx)
+		}
+		if includer.PubKey.Type() != bls.KeyType {
+			return fmt.Errorf("signer #%d has %s key", idx, includer.PubKey.Type())
+		}
+
+		// aggregation works over raw keys
+		pubKeys = append(pubKeys, rawPubKey(includer.PubKey).Bytes())
+		weight = safeAddClip(weight, policy.Weight(includer))
+	}
+	if threshold := policy.CertificateThreshold(includers); weight < threshold {
+		return fmt.Errorf("signers weight %d is below required %d", weight, threshold)
+	}
+
+	canonicalID, err := id.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if !bls.FastAggregateVerify(pubKeys, domain.Tag(canonicalID), a.Signature) {
+		return errors.New("aggregate signature is invalid")
+	}
+	return nil
+}
+
+// AggregateQuorum is a [Quorum] of includers with BLS keys, which aggregates signatures of every
+// completed certificate into a single [AggregateSignature] on finalization.
+type AggregateQuorum struct {
+	*Quorum
+
+	aggregatesMu sync.RWMutex
+	aggregates   map[string]*AggregateSignature
+}
+
+func NewAggregateQuorum(includers *Includers, opts ...Option) *AggregateQuorum {
+	return &AggregateQuorum{
+		Quorum:     NewQuorum(includers, opts...),
+		aggregates: make(map[string]*AggregateSignature, includers.Len()),
+	}
+}
+
+// Finalize finalizes the [Quorum] and aggregates signatures of its completed certificates.
+func (q *AggregateQuorum) Finalize() (bool, error) {
+	finalized, err := q.Quorum.Finalize()
+	if err != nil || !finalized {
+		return finalized, err
+	}
+
+	q.Quorum.mu.RLock()
+	aggregates := make(map[string]*AggregateSignature, len(q.Quorum.certificates))
+	for key, cert := range q.Quorum.certificates {
+		if !cert.completed {
+			continue
+		}
+
+		aggregate, err := q.aggregate(cert)
+		if err != nil {
+			q.Quorum.mu.RUnlock()
+			return false, fmt.Errorf("aggregating signatures of certificate(%s): %w", cert.msg.ID.String(), err)
+		}
+		aggregates[key] = aggregate
+	}
+	q.Quorum.mu.RUnlock()
+
+	q.aggregatesMu.Lock()
+	q.aggregates = aggregates
+	q.aggregatesMu.Unlock()
+	return true, nil
+}
+
+// Aggregate returns the [AggregateSignature] of the completed certificate as of the latest
+// finalization.
+func (q *AggregateQuorum) Aggregate(id rebro.MessageID) (*AggregateSignature, bool) {
+	q.aggregatesMu.RLock()
+	defer q.aggregatesMu.RUnlock()
+
+	aggregate, ok := q.aggregates[id.String()]
+	return aggregate, ok
+}
+
+func (q *AggregateQuorum) Delete(id rebro.MessageID) bool {
+	q.aggregatesMu.Lock()
+	delete(q.aggregates, id.String())
+	q.aggregatesMu.Unlock()
+	return q.Quorum.Delete(id)
+}
+
+// aggregate aggregates signatures of the certificate.
+// Signatures are expected to be verified and from includers, as added to the Quorum.
+func (q *AggregateQuorum) aggregate(cert *certificate) (*AggregateSignature, error) {
+	signers := make([]byte, bitmapSize(q.includers.Len()))
+	sigs := make([][]byte, len(cert.signatures))
+	for i, sig := range cert.signatures {
+		idx := q.includers.IndexByPubKey(sig.Signer)
+		if idx < 0 {
+			return nil, errors.New("the signer is not a part of includers set")
+		}
+
+		bitmapSet(signers, idx)
+		sigs[i] = sig.Body
+	}
+
+	signature, err := bls.Aggregate(sigs)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregateSignature{Signature: signature, Signers: signers}, nil
+}
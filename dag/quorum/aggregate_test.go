@@ -0,0 +1,88 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/bls"
+	"github.com/iykyk-syn/unison/dag/block"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+func TestAggregateQuorum(t *testing.T) {
+	const count = 4
+	domain := crypto.Domain{Network: "test", Version: 1, Kind: "test"}
+
+	signers := make([]*bls.Signer, count)
+	incls := make([]*Includer, count)
+	for i := range count {
+		pubKey, privKey, err := bls.GenKeys()
+		require.NoError(t, err)
+		signers[i], err = bls.NewSigner(privKey)
+		require.NoError(t, err)
+		incls[i] = NewIncluder(pubKey, 1)
+	}
+	includers := NewIncludersSet(incls)
+
+	qrm := NewAggregateQuorum(includers)
+	msgs := make([]rebro.Message, count)
+	for i, signer := range signers {
+		blk := block.NewBlock(1, signer.ID(), nil, nil, nil)
+		data, err := blk.MarshalBinary()
+		require.NoError(t, err)
+		blk.Hash()
+		msgs[i] = rebro.Message{ID: blk.ID(), Data: data}
+		require.NoError(t, qrm.Add(msgs[i]))
+	}
+
+	// every certificate is signed by a different subset of includers
+	for i, msg := range msgs {
+		canonicalID, err := msg.ID.MarshalBinary()
+		require.NoError(t, err)
+		cert, ok := qrm.Get(msg.ID)
+		require.True(t, ok)
+		for j := range count - i%2 {
+			sig, err := signers[(i+j)%count].Sign(domain.Tag(canonicalID))
+			require.NoError(t, err)
+			_, err = cert.AddSignature(sig)
+			require.NoError(t, err)
+		}
+	}
+
+	finalized, err := qrm.Finalize()
+	require.NoError(t, err)
+	require.True(t, finalized)
+
+	for _, msg := range msgs {
+		cert, ok := qrm.Get(msg.ID)
+		require.True(t, ok)
+		aggregate, ok := qrm.Aggregate(msg.ID)
+		require.True(t, ok)
+		require.NoError(t, aggregate.Verify(includers, domain, msg.ID))
+
+		// the aggregate covers exactly the signatures verified one by one
+		canonicalID, err := msg.ID.MarshalBinary()
+		require.NoError(t, err)
+		signatures := cert.Signatures()
+		assert.Equal(t, len(signatures), bitmapCount(aggregate.Signers))
+		for _, sig := range signatures {
+			require.NoError(t, bls.NewVerifier().Verify(domain.Tag(canonicalID), sig))
+			assert.True(t, bitmapHas(aggregate.Signers, includers.IndexByPubKey(sig.Signer)))
+		}
+
+		// and only verifies over the same message within the same domain
+		other := crypto.Domain{Network: "other", Version: 1, Kind: "test"}
+		assert.Error(t, aggregate.Verify(includers, other, msg.ID))
+	}
+
+	aggregate, _ := qrm.Aggregate(msgs[0].ID)
+	assert.Error(t, aggregate.Verify(includers, domain, msgs[1].ID))
+
+	// aggregates of deleted certificates are forgotten
+	require.True(t, qrm.Delete(msgs[0].ID))
+	_, ok := qrm.Aggregate(msgs[0].ID)
+	assert.False(t, ok)
+}
@@ -0,0 +1,26 @@
+package quorum
+
+import "math/bits"
+
+// bitmapSize returns the size of the bitmap for n includers.
+func bitmapSize(n int) int {
+	return (n + 7) / 8
+}
+
+// bitmapSet sets the bit of the includer with the given index.
+func bitmapSet(bitmap []byte, idx int) {
+	bitmap[idx/8] |= 1 << (idx % 8)
+}
+
+// bitmapHas reports whether the bit of the includer with the given index is set.
+func bitmapHas(bitmap []byte, idx int) bool {
+	return bitmap[idx/8]&(1<<(idx%8)) != 0
+}
+
+// bitmapCount counts the set bits.
+func bitmapCount(bitmap []byte) (n int) {
+	for _, b := range bitmap {
+		n += bits.OnesCount8(b)
+	}
+	return n
+}
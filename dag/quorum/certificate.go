@@ -1,6 +1,8 @@
 package quorum
 
 import (
+	"slices"
+
 	"github.com/iykyk-syn/unison/crypto"
 	"github.com/iykyk-syn/unison/rebro"
 )
@@ -8,10 +10,10 @@ import (
 type certificate struct {
 	quorum *Quorum
 
-	msg         rebro.Message
-	signatures  []crypto.Signature
-	activeStake int64
-	completed   bool
+	msg          rebro.Message
+	signatures   []crypto.Signature
+	activeWeight int64
+	completed    bool
 }
 
 func (c *certificate) Message() rebro.Message {
@@ -19,7 +21,10 @@ func (c *certificate) Message() rebro.Message {
 }
 
 func (c *certificate) Signatures() []crypto.Signature {
-	return c.signatures
+	c.quorum.mu.RLock()
+	defer c.quorum.mu.RUnlock()
+	// copy, as signatures may still be appended concurrently
+	return slices.Clone(c.signatures)
 }
 
 func (c *certificate) AddSignature(s crypto.Signature) (bool, error) {
@@ -0,0 +1,386 @@
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package certmsg
+
+import (
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/encoding/text"
+	"capnproto.org/go/capnp/v3/schemas"
+)
+
+type CompactCertificate capnp.Struct
+
+// CompactCertificate_TypeID is the unique identifier for the type CompactCertificate.
+const CompactCertificate_TypeID = 0xc99b52acf22aa4d4
+
+func NewCompactCertificate(s *capnp.Segment) (CompactCertificate, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CompactCertificate(st), err
+}
+
+func NewRootCompactCertificate(s *capnp.Segment) (CompactCertificate, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CompactCertificate(st), err
+}
+
+func ReadRootCompactCertificate(msg *capnp.Message) (CompactCertificate, error) {
+	root, err := msg.Root()
+	return CompactCertificate(root.Struct()), err
+}
+
+func (s CompactCertificate) String() string {
+	str, _ := text.Marshal(0xc99b52acf22aa4d4, capnp.Struct(s))
+	return str
+}
+
+func (s CompactCertificate) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CompactCertificate) DecodeFromPtr(p capnp.Ptr) CompactCertificate {
+	return CompactCertificate(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CompactCertificate) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CompactCertificate) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CompactCertificate) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CompactCertificate) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CompactCertificate) Id() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s CompactCertificate) HasId() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CompactCertificate) SetId(v []byte) error {
+	return capnp.Struct(s).SetData(0, v)
+}
+
+func (s CompactCertificate) Signers() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s CompactCertificate) HasSigners() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CompactCertificate) SetSigners(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+func (s CompactCertificate) Signatures() (capnp.DataList, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return capnp.DataList(p.List()), err
+}
+
+func (s CompactCertificate) HasSignatures() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s CompactCertificate) SetSignatures(v capnp.DataList) error {
+	return capnp.Struct(s).SetPtr(2, v.ToPtr())
+}
+
+// NewSignatures sets the signatures field to a newly
+// allocated capnp.DataList, preferring placement in s's segment.
+func (s CompactCertificate) NewSignatures(n int32) (capnp.DataList, error) {
+	l, err := capnp.NewDataList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.DataList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, l.ToPtr())
+	return l, err
+}
+
+// CompactCertificate_List is a list of CompactCertificate.
+type CompactCertificate_List = capnp.StructList[CompactCertificate]
+
+// NewCompactCertificate creates a new list of CompactCertificate.
+func NewCompactCertificate_List(s *capnp.Segment, sz int32) (CompactCertificate_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[CompactCertificate](l), err
+}
+
+// CompactCertificate_Future is a wrapper for a CompactCertificate promised by a client call.
+type CompactCertificate_Future struct{ *capnp.Future }
+
+func (f CompactCertificate_Future) Struct() (CompactCertificate, error) {
+	p, err := f.Future.Ptr()
+	return CompactCertificate(p.Struct()), err
+}
+
+type Certificate capnp.Struct
+
+// Certificate_TypeID is the unique identifier for the type Certificate.
+const Certificate_TypeID = 0xe53d4ff7fcf9c769
+
+func NewCertificate(s *capnp.Segment) (Certificate, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return Certificate(st), err
+}
+
+func NewRootCertificate(s *capnp.Segment) (Certificate, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return Certificate(st), err
+}
+
+func ReadRootCertificate(msg *capnp.Message) (Certificate, error) {
+	root, err := msg.Root()
+	return Certificate(root.Struct()), err
+}
+
+func (s Certificate) String() string {
+	str, _ := text.Marshal(0xe53d4ff7fcf9c769, capnp.Struct(s))
+	return str
+}
+
+func (s Certificate) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Certificate) DecodeFromPtr(p capnp.Ptr) Certificate {
+	return Certificate(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Certificate) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Certificate) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Certificate) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Certificate) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Certificate) NetworkId() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s Certificate) HasNetworkId() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s Certificate) NetworkIdBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s Certificate) SetNetworkId(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s Certificate) Id() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s Certificate) HasId() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s Certificate) SetId(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+func (s Certificate) Hash() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s Certificate) HasHash() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s Certificate) SetHash(v []byte) error {
+	return capnp.Struct(s).SetData(2, v)
+}
+
+func (s Certificate) Signatures() (Signature_List, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return Signature_List(p.List()), err
+}
+
+func (s Certificate) HasSignatures() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s Certificate) SetSignatures(v Signature_List) error {
+	return capnp.Struct(s).SetPtr(3, v.ToPtr())
+}
+
+// NewSignatures sets the signatures field to a newly
+// allocated Signature_List, preferring placement in s's segment.
+func (s Certificate) NewSignatures(n int32) (Signature_List, error) {
+	l, err := NewSignature_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Signature_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, l.ToPtr())
+	return l, err
+}
+
+// Certificate_List is a list of Certificate.
+type Certificate_List = capnp.StructList[Certificate]
+
+// NewCertificate creates a new list of Certificate.
+func NewCertificate_List(s *capnp.Segment, sz int32) (Certificate_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return capnp.StructList[Certificate](l), err
+}
+
+// Certificate_Future is a wrapper for a Certificate promised by a client call.
+type Certificate_Future struct{ *capnp.Future }
+
+func (f Certificate_Future) Struct() (Certificate, error) {
+	p, err := f.Future.Ptr()
+	return Certificate(p.Struct()), err
+}
+
+type Signature capnp.Struct
+
+// Signature_TypeID is the unique identifier for the type Signature.
+const Signature_TypeID = 0x92e573c18e7e4933
+
+func NewSignature(s *capnp.Segment) (Signature, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Signature(st), err
+}
+
+func NewRootSignature(s *capnp.Segment) (Signature, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Signature(st), err
+}
+
+func ReadRootSignature(msg *capnp.Message) (Signature, error) {
+	root, err := msg.Root()
+	return Signature(root.Struct()), err
+}
+
+func (s Signature) String() string {
+	str, _ := text.Marshal(0x92e573c18e7e4933, capnp.Struct(s))
+	return str
+}
+
+func (s Signature) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Signature) DecodeFromPtr(p capnp.Ptr) Signature {
+	return Signature(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Signature) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Signature) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Signature) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Signature) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Signature) Signer() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s Signature) HasSigner() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s Signature) SetSigner(v []byte) error {
+	return capnp.Struct(s).SetData(0, v)
+}
+
+func (s Signature) Signature() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s Signature) HasSignature() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s Signature) SetSignature(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+// Signature_List is a list of Signature.
+type Signature_List = capnp.StructList[Signature]
+
+// NewSignature creates a new list of Signature.
+func NewSignature_List(s *capnp.Segment, sz int32) (Signature_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[Signature](l), err
+}
+
+// Signature_Future is a wrapper for a Signature promised by a client call.
+type Signature_Future struct{ *capnp.Future }
+
+func (f Signature_Future) Struct() (Signature, error) {
+	p, err := f.Future.Ptr()
+	return Signature(p.Struct()), err
+}
+
+const schema_ee6ff9bd9443a896 = "x\xda\x8c\x92\xbfk\x13q\x18\xc6\xdf\xe7\xfb&\xfd:" +
+	"\xb4\xb5/\x97\xa9\xe8(\xd8\x0a\xd6\xd0\xba\x14\xa4\xc1\xd8" +
+	"\xa1`\xf1\xde\x88P\xbb\x1d\xc9\x99\x1e\x9a\\z?\xa8" +
+	"\x08j\x1d\x1ctp\x10q\x11\xb7\x0a\x0e\x8288:" +
+	"\xe8\"\xee\xfe\x0b\xc5\xcd\xc1E\x0a\xc2\xc9\xa519\x83" +
+	"\x14\x87\x87\x83\xe7\xe59>\x1f\xf8\x9e\x13\xd4J\xd5\xa9" +
+	"\xd0\x90\xd1\x13\xe5\x89lq\xed\xde\x93\x8f\xf1\xfeS\x92" +
+	"S\xc8\x9e\xbf\xae?\xfbp\x10~\xa7\xb2\xb1D\x8b'" +
+	"q\x07N\x15v\x90\x1d\"\xe7\x1dl\xf6uo\xfe\xc7" +
+	"\x9b\xc6\x8b/c\x13\xce'/\xf1\x09\xce{\xd8A\xde" +
+	"\x129\x0f\x8d\xcd\x82\xcf\x07\xbf~^\xb9\xb0?6)" +
+	"\xe5\x93m\xf3\x18\xce#c\x07\xf9F\xe4\xbcbKg" +
+	"\xb2\x96\xd7^\xd8N\xc3\xa8\x94v\x16\x9a~\x94t\xe2" +
+	"v\xff\x1b\xdc\x08\x9a^\xe2\x9fmz\xbdno\xf9j" +
+	"\xd0^\xe9zI\x1a\xf9.\xe0\xc2\xe81.\x11\x95@" +
+	"$s\xcb2g\xf54C\x97\x0c\x04\xa8 o\xab\x0d" +
+	"9ou\x89\xa15\x83\x958hw\xfd\xc8\x85\xc1\x14" +
+	"\xe5A\x967\xf9\xff\x08~\xa1\xaea\xc83q$O" +
+	"=\xec\xf4\xbcfR\x1f\x1dh\x0069\x04[\x9d\x95" +
+	"U\xab\x97\x18\xea\x16\xc0\xd6/\xca\xba\xd5\xcb\x0c\xdd0" +
+	"\x10c*0DrmS\xae[\xdd`hb\xc0A" +
+	"\xab\xc0t\xff\x10>\xfe'=\xfb\xfd~\x9a\xe02\xfa" +
+	"\xe7\xe9\xbf,\xcaG[\x8c\x1a\xfa\xc3?3\xe4\xf7\x1a" +
+	"\xe2[m1\xb4W\xe0\xef\xccJ\xc7\xea-\x86\xde." +
+	"\xf0\xa7\xf3\x92ZM\x18\xbak \xcc\x150\x91\xdc\xdd" +
+	"\x94\x07Vw\x19\xbag\x90u\xfdd'\x8cn\xae\x11" +
+	"\xfaz\x93\x94gL\xf6\xf8\x96\x17o\xfd\x87\xe9\xcc\xe8" +
+	"I\x13\xd5 \xb0\xae\xc1\xa1\xfb\xef\x01\x00\x0aJ\xb4\xe4"
+
+func RegisterSchema(reg *schemas.Registry) {
+	reg.Register(&schemas.Schema{
+		String: schema_ee6ff9bd9443a896,
+		Nodes: []uint64{
+			0x92e573c18e7e4933,
+			0xc99b52acf22aa4d4,
+			0xe53d4ff7fcf9c769,
+		},
+		Compressed: true,
+	})
+}
@@ -0,0 +1,192 @@
+package quorum
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/bls"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+const (
+	// committeeVersion is the version of the committee sampling VRF.
+	committeeVersion = 1
+	// committeeKind is the kind of committee sampling VRF proofs.
+	committeeKind = "quorum/committee"
+)
+
+// Sampler privately samples a committee of includers for every round of an epoch.
+//
+// Every includer evaluates a VRF on (epoch, round) with its BLS key and learns whether it is
+// a member of the round's committee. The VRF is the unique BLS signature over the input, which
+// serves as the membership proof, while its hash is the output. Each includer is sampled
+// independently with the same probability, so that the expected stake of the committee is
+// the configured one.
+type Sampler struct {
+	includers     *Includers
+	domain        crypto.Domain
+	expectedStake int64
+	// cutoff is the VRF output members are sampled below
+	cutoff uint64
+}
+
+// NewSampler instantiates a new Sampler of committees with the expected stake out of the includers.
+func NewSampler(networkID rebro.NetworkID, includers *Includers, expectedStake int64) (*Sampler, error) {
+	if expectedStake <= 0 {
+		return nil, errors.New("expected stake must be positive")
+	}
+
+	s := &Sampler{
+		includers: includers,
+		domain: crypto.Domain{
+			Network: networkID.String(),
+			Version: committeeVersion,
+			Kind:    committeeKind,
+		},
+		expectedStake: min(expectedStake, includers.TotalStake()),
+		cutoff:        math.MaxUint64,
+	}
+	if s.expectedStake < includers.TotalStake() {
+		// the probability of sampling is expected stake out of total
+		s.cutoff, _ = bits.Div64(uint64(s.expectedStake), 0, uint64(includers.TotalStake()))
+	}
+	return s, nil
+}
+
+// ExpectedStake returns the expected stake of sampled committees.
+func (s *Sampler) ExpectedStake() int64 {
+	return s.expectedStake
+}
+
+// Membership is the result of the committee sampling for an includer.
+type Membership struct {
+	// Proof is the VRF proof of the includer, which members attach to their messages.
+	Proof []byte
+	// Selected tells whether the includer is a member of the committee.
+	Selected bool
+}
+
+// Evaluate evaluates the VRF of the signer for the round of the epoch.
+// The signer must be an includer with a BLS key.
+func (s *Sampler) Evaluate(signer crypto.Signer, epoch, round uint64) (*Membership, error) {
+	if _, err := s.includer(signer.ID()); err != nil {
+		return nil, err
+	}
+
+	sig, err := signer.Sign(s.input(epoch, round))
+	if err != nil {
+		return nil, err
+	}
+	return &Membership{Proof: sig.Body, Selected: s.selected(sig.Body)}, nil
+}
+
+// Verify verifies the proof of the includer's membership in the committee of the round.
+func (s *Sampler) Verify(pubKey []byte, epoch, round uint64, proof []byte) error {
+	includer, err := s.includer(pubKey)
+	if err != nil {
+		return err
+	}
+	if !includer.PubKey.VerifySignature(s.input(epoch, round), proof) {
+		return errors.New("membership proof is invalid")
+	}
+	if !s.selected(proof) {
+		return fmt.Errorf("includer(%X) is not a member of the committee", pubKey)
+	}
+	return nil
+}
+
+// Committee returns the [Committee] of the round with thresholds exceeding fractions of
+// the expected stake.
+func (s *Sampler) Committee(epoch, round uint64, certificate, finalization Fraction) *Committee {
+	return &Committee{
+		sampler:      s,
+		epoch:        epoch,
+		round:        round,
+		certificate:  certificate,
+		finalization: finalization,
+		members:      make(map[string]struct{}),
+	}
+}
+
+func (s *Sampler) includer(pubKey []byte) (*Includer, error) {
+	includer := s.includers.GetByPubKey(pubKey)
+	if includer == nil {
+		return nil, fmt.Errorf("signer(%X) is not a part of includers set", pubKey)
+	}
+	if includer.PubKey.Type() != bls.KeyType {
+		return nil, fmt.Errorf("signer(%X) has %s key", pubKey, includer.PubKey.Type())
+	}
+	return includer, nil
+}
+
+func (s *Sampler) input(epoch, round uint64) []byte {
+	input := make([]byte, 16)
+	binary.BigEndian.PutUint64(input, epoch)
+	binary.BigEndian.PutUint64(input[8:], round)
+	return s.domain.Tag(input)
+}
+
+func (s *Sampler) selected(proof []byte) bool {
+	if s.cutoff == math.MaxUint64 {
+		return true
+	}
+
+	output := sha256.Sum256(proof)
+	return binary.BigEndian.Uint64(output[:8]) < s.cutoff
+}
+
+// Committee is a [Policy] for a round counting only stake of includers admitted with proofs of
+// membership in the round's committee, with thresholds computed over the expected stake of
+// the committee.
+type Committee struct {
+	sampler      *Sampler
+	epoch, round uint64
+	certificate  Fraction
+	finalization Fraction
+
+	membersMu sync.RWMutex
+	members   map[string]struct{}
+}
+
+// Admit verifies the membership proof of the includer and admits it to the Committee.
+func (c *Committee) Admit(pubKey []byte, proof []byte) error {
+	if err := c.sampler.Verify(pubKey, c.epoch, c.round, proof); err != nil {
+		return err
+	}
+
+	c.membersMu.Lock()
+	c.members[string(pubKey)] = struct{}{}
+	c.membersMu.Unlock()
+	return nil
+}
+
+// Len returns the number of admitted members.
+func (c *Committee) Len() int {
+	c.membersMu.RLock()
+	defer c.membersMu.RUnlock()
+	return len(c.members)
+}
+
+func (c *Committee) Weight(includer *Includer) int64 {
+	c.membersMu.RLock()
+	defer c.membersMu.RUnlock()
+
+	if _, ok := c.members[string(includer.PubKey.Bytes())]; !ok {
+		return 0
+	}
+	return includer.Stake
+}
+
+func (c *Committee) CertificateThreshold(*Includers) int64 {
+	return c.certificate.threshold(c.sampler.expectedStake)
+}
+
+func (c *Committee) FinalizationThreshold(*Includers) int64 {
+	return c.finalization.threshold(c.sampler.expectedStake)
+}
@@ -0,0 +1,74 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto/bls"
+)
+
+func TestCommittee(t *testing.T) {
+	const count = 16
+
+	signers := make([]*bls.Signer, count)
+	incls := make([]*Includer, count)
+	for i := range count {
+		pubKey, privKey, err := bls.GenKeys()
+		require.NoError(t, err)
+		signers[i], err = bls.NewSigner(privKey)
+		require.NoError(t, err)
+		incls[i] = NewIncluder(pubKey, 1)
+	}
+	includers := NewIncludersSet(incls)
+
+	sampler, err := NewSampler("test", includers, count/2)
+	require.NoError(t, err)
+	committee := sampler.Committee(1, 1, TwoThirds, TwoThirds)
+	assert.EqualValues(t, 6, committee.CertificateThreshold(includers))
+	assert.EqualValues(t, 6, committee.FinalizationThreshold(includers))
+
+	var members int
+	for _, signer := range signers {
+		membership, err := sampler.Evaluate(signer, 1, 1)
+		require.NoError(t, err)
+
+		// the VRF is deterministic
+		again, err := sampler.Evaluate(signer, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, membership, again)
+
+		includer := includers.GetByPubKey(signer.ID())
+		err = committee.Admit(signer.ID(), membership.Proof)
+		if !membership.Selected {
+			assert.Error(t, err)
+			assert.Zero(t, committee.Weight(includer))
+			continue
+		}
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, committee.Weight(includer))
+		members++
+
+		// proofs are bound to the round
+		other := sampler.Committee(1, 2, TwoThirds, TwoThirds)
+		otherMembership, err := sampler.Evaluate(signer, 1, 2)
+		require.NoError(t, err)
+		if !otherMembership.Selected {
+			assert.Error(t, other.Admit(signer.ID(), otherMembership.Proof))
+		}
+		assert.Error(t, sampler.Verify(signer.ID(), 2, 1, membership.Proof))
+	}
+	assert.Equal(t, members, committee.Len())
+	// the probability of sampling nobody out of 16 with 1/2 chance is negligible
+	assert.NotZero(t, members)
+	assert.Less(t, members, count)
+
+	// the committee can't exceed the includers
+	whole, err := NewSampler("test", includers, count*2)
+	require.NoError(t, err)
+	assert.EqualValues(t, count, whole.ExpectedStake())
+	membership, err := whole.Evaluate(signers[0], 1, 1)
+	require.NoError(t, err)
+	assert.True(t, membership.Selected)
+}
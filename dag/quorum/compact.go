@@ -0,0 +1,219 @@
+package quorum
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"capnproto.org/go/capnp/v3"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/dag/quorum/certmsg"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// CompactCertificate is a certificate referencing its signers by their index in the agreed and
+// deterministically sorted [Includers] set instead of carrying their public keys.
+type CompactCertificate struct {
+	// ID is the MessageID the certificate attests to.
+	ID rebro.MessageID
+	// Signers is the bitmap of signers, where bit i is set if the includer with index i
+	// in the set has signed.
+	Signers []byte
+	// Signatures are signature bodies of the signers in the order of their indices.
+	Signatures [][]byte
+}
+
+// NewCompactCertificate compacts the certificate against the includers set.
+func NewCompactCertificate(cert rebro.Certificate, includers *Includers) (*CompactCertificate, error) {
+	type indexed struct {
+		idx  int
+		body []byte
+	}
+
+	sigs := cert.Signatures()
+	signed := make([]indexed, 0, len(sigs))
+	signers := make([]byte, bitmapSize(includers.Len()))
+	for _, sig := range sigs {
+		idx := includers.IndexByPubKey(sig.Signer)
+		if idx < 0 {
+			return nil, fmt.Errorf("signer(%X) is not a part of includers set", sig.Signer)
+		}
+		if bitmapHas(signers, idx) {
+			return nil, fmt.Errorf("duplicate signature from signer(%X)", sig.Signer)
+		}
+
+		bitmapSet(signers, idx)
+		signed = append(signed, indexed{idx: idx, body: sig.Body})
+	}
+	slices.SortFunc(signed, func(a, b indexed) int {
+		return a.idx - b.idx
+	})
+
+	compact := &CompactCertificate{
+		ID:         cert.Message().ID,
+		Signers:    signers,
+		Signatures: make([][]byte, len(signed)),
+	}
+	for i, sig := range signed {
+		compact.Signatures[i] = sig.body
+	}
+	return compact, nil
+}
+
+// Expand restores signatures of the CompactCertificate with public keys of the signers.
+func (c *CompactCertificate) Expand(includers *Includers) ([]crypto.Signature, error) {
+	if len(c.Signers) != bitmapSize(includers.Len()) {
+		return nil, fmt.Errorf("signers bitmap of %d bytes for %d includers", len(c.Signers), includers.Len())
+	}
+	if bitmapCount(c.Signers) != len(c.Signatures) {
+		return nil, fmt.Errorf("%d signers for %d signatures", bitmapCount(c.Signers), len(c.Signatures))
+	}
+
+	sigs := make([]crypto.Signature, 0, len(c.Signatures))
+	for idx := range len(c.Signers) * 8 {
+		if !bitmapHas(c.Signers, idx) {
+			continue
+		}
+
+		includer := includers.GetByIndex(idx)
+		if includer == nil {
+			return nil, fmt.Errorf("signer #%d is not a part of includers set", idx)
+		}
+		sigs = append(sigs, crypto.Signature{
+			Body:   c.Signatures[len(sigs)],
+			Signer: includer.PubKey.Bytes(),
+		})
+	}
+	return sigs, nil
+}
+
+// Certificate restores the certificate of the message the CompactCertificate attests to.
+func (c *CompactCertificate) Certificate(
+	msg rebro.Message,
+	includers *Includers,
+	opts ...Option,
+) (rebro.Certificate, error) {
+	if msg.ID.String() != c.ID.String() {
+		return nil, fmt.Errorf("message(%s) does not match certificate(%s)", msg.ID.String(), c.ID.String())
+	}
+
+	sigs, err := c.Expand(includers)
+	if err != nil {
+		return nil, err
+	}
+
+	qrm := NewQuorum(includers, opts...)
+	if err := qrm.Add(msg); err != nil {
+		return nil, err
+	}
+	cert, _ := qrm.Get(msg.ID)
+	for _, sig := range sigs {
+		if _, err := cert.AddSignature(sig); err != nil {
+			return nil, err
+		}
+	}
+	return cert, nil
+}
+
+// MarshalBinary encodes the CompactCertificate in the canonical form.
+func (c *CompactCertificate) MarshalBinary() ([]byte, error) {
+	canonicalID, err := c.ID.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := certmsg.NewRootCompactCertificate(seg)
+	if err != nil {
+		return nil, err
+	}
+	if err = cert.SetId(canonicalID); err != nil {
+		return nil, err
+	}
+	if err = cert.SetSigners(c.Signers); err != nil {
+		return nil, err
+	}
+	sigs, err := cert.NewSignatures(int32(len(c.Signatures)))
+	if err != nil {
+		return nil, err
+	}
+	for i, sig := range c.Signatures {
+		if err = sigs.Set(i, sig); err != nil {
+			return nil, err
+		}
+	}
+
+	return marshalCanonical(capnp.Struct(cert))
+}
+
+// UnmarshalCompactCertificate decodes the CompactCertificate from its canonical form.
+func UnmarshalCompactCertificate(data []byte, decoder rebro.MessageIDDecoder) (*CompactCertificate, error) {
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := certmsg.ReadRootCompactCertificate(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkCanonical(capnp.Struct(cert), data); err != nil {
+		return nil, err
+	}
+
+	canonicalID, err := cert.Id()
+	if err != nil {
+		return nil, err
+	}
+	id, err := decoder(canonicalID)
+	if err != nil {
+		return nil, fmt.Errorf("decoding MessageID: %w", err)
+	}
+
+	compact := &CompactCertificate{ID: id}
+	compact.Signers, err = cert.Signers()
+	if err != nil {
+		return nil, err
+	}
+	sigs, err := cert.Signatures()
+	if err != nil {
+		return nil, err
+	}
+	compact.Signatures = make([][]byte, sigs.Len())
+	for i := range sigs.Len() {
+		compact.Signatures[i], err = sigs.At(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return compact, nil
+}
+
+// marshalCanonical encodes the root struct as a single segment message in the canonical form.
+func marshalCanonical(root capnp.Struct) ([]byte, error) {
+	data, err := capnp.Canonicalize(root)
+	if err != nil {
+		return nil, err
+	}
+	msg := &capnp.Message{Arena: capnp.SingleSegment(data)}
+	return msg.Marshal()
+}
+
+// checkCanonical ensures the data is the canonical encoding of the decoded root struct,
+// so that every value has exactly one valid encoding.
+func checkCanonical(root capnp.Struct, data []byte) error {
+	canonical, err := marshalCanonical(root)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(canonical, data) {
+		return errors.New("non-canonical encoding")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/dag/block"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+func TestCompactCertificate(t *testing.T) {
+	privKeys, includers := newIncluders(t, 10)
+	msg := newMessage(t, privKeys[0])
+
+	qrm := NewQuorum(includers)
+	require.NoError(t, qrm.Add(msg))
+	cert, ok := qrm.Get(msg.ID)
+	require.True(t, ok)
+	for _, privKey := range privKeys[3:] {
+		_, err := cert.AddSignature(sign(t, privKey, msg))
+		require.NoError(t, err)
+	}
+
+	compact, err := NewCompactCertificate(cert, includers)
+	require.NoError(t, err)
+	assert.Len(t, compact.Signers, 2)
+	assert.Equal(t, 7, bitmapCount(compact.Signers))
+	assert.Len(t, compact.Signatures, 7)
+
+	data, err := compact.MarshalBinary()
+	require.NoError(t, err)
+	decoded, err := UnmarshalCompactCertificate(data, block.UnmarshalBlockID)
+	require.NoError(t, err)
+	assert.Equal(t, compact.ID.String(), decoded.ID.String())
+	assert.Equal(t, compact.Signers, decoded.Signers)
+	assert.Equal(t, compact.Signatures, decoded.Signatures)
+
+	// the encoding is canonical
+	again, err := decoded.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, data, again)
+	_, err = UnmarshalCompactCertificate(append(data, make([]byte, 8)...), block.UnmarshalBlockID)
+	assert.Error(t, err)
+
+	restored, err := decoded.Certificate(msg, includers)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, cert.Signatures(), restored.Signatures())
+
+	// signers are only meaningful against the same includers set
+	_, otherIncluders := newIncluders(t, 4)
+	_, err = decoded.Expand(otherIncluders)
+	assert.Error(t, err)
+	_, err = NewCompactCertificate(cert, otherIncluders)
+	assert.Error(t, err)
+}
+
+func newIncluders(t *testing.T, count int) ([]ed25519.PrivateKey, *Includers) {
+	privKeys := make([]ed25519.PrivateKey, count)
+	incls := make([]*Includer, count)
+	for i := range count {
+		pubKey, privKey, err := ed25519.GenKeys()
+		require.NoError(t, err)
+		privKeys[i] = privKey
+		incls[i] = NewIncluder(pubKey, 1)
+	}
+	return privKeys, NewIncludersSet(incls)
+}
+
+func newMessage(t *testing.T, privKey ed25519.PrivateKey) rebro.Message {
+	blk := block.NewBlock(1, privKey.PubKey().Bytes(), nil, nil, nil)
+	data, err := blk.MarshalBinary()
+	require.NoError(t, err)
+	blk.Hash()
+	return rebro.Message{ID: blk.ID(), Data: data}
+}
+
+func sign(t *testing.T, privKey ed25519.PrivateKey, msg rebro.Message) crypto.Signature {
+	canonicalID, err := msg.ID.MarshalBinary()
+	require.NoError(t, err)
+	body, err := privKey.Sign(canonicalID)
+	require.NoError(t, err)
+	return crypto.Signature{Body: body, Signer: privKey.PubKey().Bytes()}
+}
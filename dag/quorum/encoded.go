@@ -0,0 +1,194 @@
+package quorum
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"capnproto.org/go/capnp/v3"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/dag/quorum/certmsg"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// Certificate is a self-contained certificate that can be stored, sent to clients and verified
+// offline with [Verify] against the includers set of its round.
+type Certificate struct {
+	// NetworkID is the network the certificate was produced in.
+	NetworkID rebro.NetworkID
+	// ID is the MessageID the certificate attests to.
+	ID rebro.MessageID
+	// Hash is the hash of the message the certificate attests to.
+	Hash []byte
+	// Signatures are signatures over the canonical MessageID sorted by their signers.
+	Signatures []crypto.Signature
+}
+
+// NewCertificate makes a Certificate out of the certificate produced in the network.
+func NewCertificate(networkID rebro.NetworkID, cert rebro.Certificate) *Certificate {
+	sigs := cert.Signatures()
+	slices.SortFunc(sigs, func(a, b crypto.Signature) int {
+		return bytes.Compare(a.Signer, b.Signer)
+	})
+
+	id := cert.Message().ID
+	return &Certificate{
+		NetworkID:  networkID,
+		ID:         id,
+		Hash:       id.Hash(),
+		Signatures: sigs,
+	}
+}
+
+// Verify verifies the Certificate of the network against the includers set without a running
+// broadcaster. It recomputes the weight of the signers by the [Policy] and checks every signature
+// over the canonical MessageID with the verifier, which must verify within the domain the signatures
+// were produced in.
+func Verify(
+	cert *Certificate,
+	networkID rebro.NetworkID,
+	includers *Includers,
+	verifier crypto.Verifier,
+	opts ...Option,
+) error {
+	policy := newOptions(opts).policy
+
+	if cert.NetworkID != networkID {
+		return fmt.Errorf("certificate of network %s instead of %s", cert.NetworkID, networkID)
+	}
+	if !bytes.Equal(cert.Hash, cert.ID.Hash()) {
+		return fmt.Errorf("hash(%X) does not match MessageID(%s)", cert.Hash, cert.ID.String())
+	}
+	if err := cert.ID.Validate(); err != nil {
+		return err
+	}
+	if includers.GetByPubKey(cert.ID.Signer()) == nil {
+		return fmt.Errorf("message signer(%X) is not a part of includers set", cert.ID.Signer())
+	}
+
+	canonicalID, err := cert.ID.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var weight int64
+	signers := make([]byte, bitmapSize(includers.Len()))
+	for _, sig := range cert.Signatures {
+		idx := includers.IndexByPubKey(sig.Signer)
+		if idx < 0 {
+			return fmt.Errorf("signer(%X) is not a part of includers set", sig.Signer)
+		}
+		if bitmapHas(signers, idx) {
+			return fmt.Errorf("duplicate signature from signer(%X)", sig.Signer)
+		}
+		if err := verifier.Verify(canonicalID, sig); err != nil {
+			return fmt.Errorf("verifying signature of signer(%X): %w", sig.Signer, err)
+		}
+
+		bitmapSet(signers, idx)
+		weight = safeAddClip(weight, policy.Weight(includers.GetByIndex(idx)))
+	}
+	if threshold := policy.CertificateThreshold(includers); weight < threshold {
+		return fmt.Errorf("signers weight %d is below required %d", weight, threshold)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the Certificate in the canonical form.
+func (c *Certificate) MarshalBinary() ([]byte, error) {
+	canonicalID, err := c.ID.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := certmsg.NewRootCertificate(seg)
+	if err != nil {
+		return nil, err
+	}
+	if err = cert.SetNetworkId(c.NetworkID.String()); err != nil {
+		return nil, err
+	}
+	if err = cert.SetId(canonicalID); err != nil {
+		return nil, err
+	}
+	if err = cert.SetHash(c.Hash); err != nil {
+		return nil, err
+	}
+	sigs, err := cert.NewSignatures(int32(len(c.Signatures)))
+	if err != nil {
+		return nil, err
+	}
+	for i, sig := range c.Signatures {
+		if err = sigs.At(i).SetSigner(sig.Signer); err != nil {
+			return nil, err
+		}
+		if err = sigs.At(i).SetSignature(sig.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	return marshalCanonical(capnp.Struct(cert))
+}
+
+// UnmarshalCertificate decodes the Certificate from its canonical form.
+func UnmarshalCertificate(data []byte, decoder rebro.MessageIDDecoder) (*Certificate, error) {
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := certmsg.ReadRootCertificate(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkCanonical(capnp.Struct(cert), data); err != nil {
+		return nil, err
+	}
+
+	networkID, err := cert.NetworkId()
+	if err != nil {
+		return nil, err
+	}
+	canonicalID, err := cert.Id()
+	if err != nil {
+		return nil, err
+	}
+	id, err := decoder(canonicalID)
+	if err != nil {
+		return nil, fmt.Errorf("decoding MessageID: %w", err)
+	}
+
+	decoded := &Certificate{NetworkID: rebro.NetworkID(networkID), ID: id}
+	decoded.Hash, err = cert.Hash()
+	if err != nil {
+		return nil, err
+	}
+	sigs, err := cert.Signatures()
+	if err != nil {
+		return nil, err
+	}
+	decoded.Signatures = make([]crypto.Signature, sigs.Len())
+	for i := range sigs.Len() {
+		decoded.Signatures[i].Signer, err = sigs.At(i).Signer()
+		if err != nil {
+			return nil, err
+		}
+		decoded.Signatures[i].Body, err = sigs.At(i).Signature()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !slices.IsSortedFunc(decoded.Signatures, func(a, b crypto.Signature) int {
+		return bytes.Compare(a.Signer, b.Signer)
+	}) {
+		return nil, errors.New("signatures are not sorted by signers")
+	}
+	return decoded, nil
+}
@@ -0,0 +1,76 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/local"
+	"github.com/iykyk-syn/unison/dag/block"
+)
+
+func TestCertificateVerify(t *testing.T) {
+	privKeys, includers := newIncluders(t, 10)
+	msg := newMessage(t, privKeys[0])
+	verifier := local.NewVerifier()
+
+	qrm := NewQuorum(includers)
+	require.NoError(t, qrm.Add(msg))
+	cert, ok := qrm.Get(msg.ID)
+	require.True(t, ok)
+	for _, privKey := range privKeys[3:] {
+		_, err := cert.AddSignature(sign(t, privKey, msg))
+		require.NoError(t, err)
+	}
+
+	full := NewCertificate("test", cert)
+	require.NoError(t, Verify(full, "test", includers, verifier))
+
+	data, err := full.MarshalBinary()
+	require.NoError(t, err)
+	decoded, err := UnmarshalCertificate(data, block.UnmarshalBlockID)
+	require.NoError(t, err)
+	assert.Equal(t, full.NetworkID, decoded.NetworkID)
+	assert.Equal(t, full.ID.String(), decoded.ID.String())
+	assert.Equal(t, full.Hash, decoded.Hash)
+	assert.Equal(t, full.Signatures, decoded.Signatures)
+	require.NoError(t, Verify(decoded, "test", includers, verifier))
+
+	// the encoding is canonical
+	again, err := decoded.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, data, again)
+	_, err = UnmarshalCertificate(append(data, make([]byte, 8)...), block.UnmarshalBlockID)
+	assert.Error(t, err)
+
+	// not enough stake
+	short := *decoded
+	short.Signatures = decoded.Signatures[:6]
+	assert.Error(t, Verify(&short, "test", includers, verifier))
+
+	// duplicate signatures do not add stake
+	duplicate := *decoded
+	duplicate.Signatures = append(decoded.Signatures[:6:6], decoded.Signatures[0])
+	assert.Error(t, Verify(&duplicate, "test", includers, verifier))
+
+	// invalid signature
+	forged := *decoded
+	forged.Signatures = append([]crypto.Signature(nil), decoded.Signatures...)
+	forged.Signatures[0].Body = append([]byte(nil), forged.Signatures[0].Body...)
+	forged.Signatures[0].Body[0] ^= 1
+	assert.Error(t, Verify(&forged, "test", includers, verifier))
+
+	// mismatched hash
+	mismatched := *decoded
+	mismatched.Hash = make([]byte, len(decoded.Hash))
+	assert.Error(t, Verify(&mismatched, "test", includers, verifier))
+
+	// certificates are only valid in their network
+	assert.Error(t, Verify(decoded, "other", includers, verifier))
+
+	// signatures are only meaningful against the same includers set
+	_, otherIncluders := newIncluders(t, 4)
+	assert.Error(t, Verify(decoded, "test", otherIncluders, verifier))
+}
@@ -2,12 +2,16 @@ package quorum
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"math"
-	"sort"
+	"slices"
+
+	"capnproto.org/go/capnp/v3"
 
 	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/dag/quorum/includermsg"
 )
 
 // MaxStake - the maximum allowed stake.
@@ -42,16 +46,29 @@ func (i *Includer) Validate() error {
 }
 
 // Includers contains all available includers (+ the signer),
-// sorted by the stake amount in a decreasing order
+// sorted by the stake amount in a decreasing order and then by public keys, so that every
+// includer has the same stable index across nodes agreeing on the set.
 type Includers struct {
 	includers []*Includer
+	// index maps raw public keys to indices of includers
+	index map[string]int
+	// described maps self-describing encodings of public keys to indices of includers, so that
+	// signers are found by either form regardless of the form the set was built or decoded with
+	described map[string]int
 
 	totalStake int64
+	hash       []byte
+	// hashErr is set if the set cannot be committed to
+	hashErr error
 }
 
 func NewIncludersSet(v []*Includer) *Includers {
 	set := &Includers{includers: v}
-	sort.Sort(set)
+	slices.SortFunc(set.includers, compareIncluders)
+	// computed eagerly, so that the set is safe for concurrent reads
+	set.updateIndex()
+	set.updateTotalStake()
+	set.updateHash()
 	return set
 }
 
@@ -65,26 +82,137 @@ func (incl *Includers) Validate() error {
 			return fmt.Errorf("invalid includer #%d: %w", idx, err)
 		}
 	}
+	if len(incl.index) != len(incl.includers) {
+		return errors.New("includers have duplicate public keys")
+	}
 
 	return nil
 }
 
 func (incl *Includers) GetByPubKey(pubK []byte) *Includer {
-	for _, v := range incl.includers {
-		if v.PubKey.Equals(pubK) {
-			return v
-		}
+	return incl.GetByIndex(incl.IndexByPubKey(pubK))
+}
+
+// IndexByPubKey returns the index of the includer in the set, or -1 if it is not in the set.
+func (incl *Includers) IndexByPubKey(pubK []byte) int {
+	if idx, ok := incl.index[string(pubK)]; ok {
+		return idx
 	}
-	return nil
+	if idx, ok := incl.described[string(pubK)]; ok {
+		return idx
+	}
+	return -1
 }
 
-func (incl *Includers) TotalStake() int64 {
-	if incl.totalStake == 0 {
-		incl.updateTotalStake()
+// GetByIndex returns the includer by its index in the set.
+func (incl *Includers) GetByIndex(idx int) *Includer {
+	if idx < 0 || idx >= len(incl.includers) {
+		return nil
 	}
+	return incl.includers[idx]
+}
+
+func (incl *Includers) TotalStake() int64 {
 	return incl.totalStake
 }
 
+// Hash returns the hash of the canonical serialization of the set, which commits to
+// the includers, their stakes and indices. Invalid sets are not committed to and error out.
+func (incl *Includers) Hash() ([]byte, error) {
+	return incl.hash, incl.hashErr
+}
+
+// MarshalBinary encodes the set in the canonical form with self-describing public keys.
+func (incl *Includers) MarshalBinary() ([]byte, error) {
+	if err := incl.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := includermsg.NewRootIncluders(seg)
+	if err != nil {
+		return nil, err
+	}
+	list, err := set.NewIncluders(int32(len(incl.includers)))
+	if err != nil {
+		return nil, err
+	}
+	for i, includer := range incl.includers {
+		pubKey, err := crypto.MarshalPubKey(includer.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("encoding includer #%d: %w", i, err)
+		}
+		if err = list.At(i).SetPubKey(pubKey); err != nil {
+			return nil, err
+		}
+		list.At(i).SetStake(includer.Stake)
+	}
+
+	return marshalCanonical(capnp.Struct(set))
+}
+
+// UnmarshalIncluders decodes the set from its canonical form.
+// Schemes of the public keys must be registered with [crypto.RegisterScheme].
+func UnmarshalIncluders(data []byte) (*Includers, error) {
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := includermsg.ReadRootIncluders(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkCanonical(capnp.Struct(set), data); err != nil {
+		return nil, err
+	}
+
+	list, err := set.Includers()
+	if err != nil {
+		return nil, err
+	}
+	incls := make([]*Includer, list.Len())
+	for i := range list.Len() {
+		keyBytes, err := list.At(i).PubKey()
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := crypto.UnmarshalPubKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("decoding includer #%d: %w", i, err)
+		}
+		incls[i] = NewIncluder(pubKey, list.At(i).Stake())
+	}
+	if !slices.IsSortedFunc(incls, compareIncluders) {
+		return nil, errors.New("includers are not sorted")
+	}
+
+	includers := NewIncludersSet(incls)
+	if err = includers.Validate(); err != nil {
+		return nil, err
+	}
+	return includers, nil
+}
+
+func (incl *Includers) updateIndex() {
+	incl.index = make(map[string]int, len(incl.includers))
+	incl.described = make(map[string]int, len(incl.includers))
+	for idx, v := range incl.includers {
+		if v.PubKey == nil {
+			continue
+		}
+		incl.index[string(rawPubKey(v.PubKey).Bytes())] = idx
+		// keys of unknown schemes are not committed to by the set anyway
+		if encoded, err := crypto.MarshalPubKey(v.PubKey); err == nil {
+			incl.described[string(encoded)] = idx
+		}
+	}
+}
+
 func (incl *Includers) updateTotalStake() {
 	sum := int64(0)
 	for _, val := range incl.includers {
@@ -100,17 +228,36 @@ func (incl *Includers) updateTotalStake() {
 	incl.totalStake = sum
 }
 
+func (incl *Includers) updateHash() {
+	data, err := incl.MarshalBinary()
+	if err != nil {
+		incl.hashErr = fmt.Errorf("hashing includers: %w", err)
+		return
+	}
+	hash := sha256.Sum256(data)
+	incl.hash = hash[:]
+}
+
 func (incl *Includers) Len() int { return len(incl.includers) }
 
-func (incl *Includers) Less(i, j int) bool {
-	if incl.includers[i].Stake == incl.includers[j].Stake {
-		return bytes.Compare(incl.includers[i].PubKey.Bytes(), incl.includers[j].PubKey.Bytes()) == -1
+// compareIncluders orders includers by the stake amount in a decreasing order and then by raw
+// public keys, so that the order does not depend on the form of the keys.
+func compareIncluders(a, b *Includer) int {
+	if a.Stake == b.Stake {
+		return bytes.Compare(rawPubKey(a.PubKey).Bytes(), rawPubKey(b.PubKey).Bytes())
+	}
+	if a.Stake > b.Stake {
+		return -1
 	}
-	return incl.includers[i].Stake > incl.includers[j].Stake
+	return 1
 }
 
-func (incl *Includers) Swap(i, j int) {
-	incl.includers[i], incl.includers[j] = incl.includers[j], incl.includers[i]
+// rawPubKey unwraps the self-describing public key.
+func rawPubKey(pubKey crypto.PubKey) crypto.PubKey {
+	if described, ok := pubKey.(*crypto.DescribedPubKey); ok {
+		return described.PubKey
+	}
+	return pubKey
 }
 
 func safeAddClip(a, b int64) int64 {
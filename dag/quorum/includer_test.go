@@ -0,0 +1,109 @@
+package quorum
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/bls"
+	"github.com/iykyk-syn/unison/crypto/ed25519"
+)
+
+func TestIncluders(t *testing.T) {
+	incls := make([]*Includer, 0, 8)
+	for i := range 6 {
+		pubKey, _, err := ed25519.GenKeys()
+		require.NoError(t, err)
+		incls = append(incls, NewIncluder(pubKey, int64(i%3+1)))
+	}
+	for range 2 {
+		pubKey, _, err := bls.GenKeys()
+		require.NoError(t, err)
+		incls = append(incls, NewIncluder(pubKey, 2))
+	}
+
+	includers := NewIncludersSet(slices.Clone(incls))
+	require.NoError(t, includers.Validate())
+	for idx := range includers.Len() {
+		includer := includers.GetByIndex(idx)
+		assert.Equal(t, idx, includers.IndexByPubKey(includer.PubKey.Bytes()))
+		assert.Same(t, includer, includers.GetByPubKey(includer.PubKey.Bytes()))
+	}
+	assert.Nil(t, includers.GetByPubKey([]byte("unknown")))
+	assert.Equal(t, -1, includers.IndexByPubKey([]byte("unknown")))
+
+	hash := func(set *Includers) []byte {
+		h, err := set.Hash()
+		require.NoError(t, err)
+		return h
+	}
+
+	// indices and the hash are independent of the order includers are given in
+	slices.Reverse(incls)
+	reversed := NewIncludersSet(slices.Clone(incls))
+	for idx := range includers.Len() {
+		assert.Same(t, includers.GetByIndex(idx), reversed.GetByIndex(idx))
+	}
+	assert.Equal(t, hash(includers), hash(reversed))
+
+	data, err := includers.MarshalBinary()
+	require.NoError(t, err)
+	decoded, err := UnmarshalIncluders(data)
+	require.NoError(t, err)
+	assert.Equal(t, hash(includers), hash(decoded))
+	assert.Equal(t, includers.TotalStake(), decoded.TotalStake())
+	for idx := range includers.Len() {
+		assert.Equal(t, includers.GetByIndex(idx).PubKey.Bytes(), decoded.GetByIndex(idx).PubKey.Bytes())
+		assert.Equal(t, includers.GetByIndex(idx).Stake, decoded.GetByIndex(idx).Stake)
+	}
+	_, err = UnmarshalIncluders(append(data, make([]byte, 8)...))
+	assert.Error(t, err)
+
+	// diverging views have different hashes
+	changed := slices.Clone(incls)
+	changed[0] = NewIncluder(changed[0].PubKey, changed[0].Stake+1)
+	assert.NotEqual(t, hash(includers), hash(NewIncludersSet(changed)))
+	assert.NotEqual(t, hash(includers), hash(NewIncludersSet(slices.Clone(incls[1:]))))
+
+	// duplicate includers are invalid
+	duplicate := NewIncludersSet(append(slices.Clone(incls), NewIncluder(incls[0].PubKey, 1)))
+	assert.Error(t, duplicate.Validate())
+	_, err = duplicate.Hash()
+	assert.Error(t, err)
+}
+
+func TestDescribedIncluders(t *testing.T) {
+	incls := make([]*Includer, 0, 6)
+	described := make([]*Includer, 0, 6)
+	for i := range 6 {
+		pubKey, _, err := ed25519.GenKeys()
+		require.NoError(t, err)
+		describedKey, err := crypto.Describe(pubKey)
+		require.NoError(t, err)
+		incls = append(incls, NewIncluder(pubKey, int64(i%2+1)))
+		described = append(described, NewIncluder(describedKey, int64(i%2+1)))
+	}
+
+	raw, err := NewIncludersSet(incls).MarshalBinary()
+	require.NoError(t, err)
+	data, err := NewIncludersSet(described).MarshalBinary()
+	require.NoError(t, err)
+	// the form of the keys affects neither the order nor the encoding
+	assert.Equal(t, raw, data)
+
+	decoded, err := UnmarshalIncluders(data)
+	require.NoError(t, err)
+	for _, set := range []*Includers{NewIncludersSet(described), decoded} {
+		// includers are found by both forms of their keys after the round trip just as before it
+		for _, incl := range described {
+			pubKey := incl.PubKey.(*crypto.DescribedPubKey)
+			idx := set.IndexByPubKey(pubKey.Bytes())
+			require.NotEqual(t, -1, idx)
+			assert.Equal(t, idx, set.IndexByPubKey(pubKey.PubKey.Bytes()))
+			assert.Equal(t, incl.Stake, set.GetByIndex(idx).Stake)
+		}
+	}
+}
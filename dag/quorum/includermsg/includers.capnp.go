@@ -0,0 +1,211 @@
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package includermsg
+
+import (
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/encoding/text"
+	"capnproto.org/go/capnp/v3/schemas"
+)
+
+type Includers capnp.Struct
+
+// Includers_TypeID is the unique identifier for the type Includers.
+const Includers_TypeID = 0x870c91d5b9fdf1fe
+
+func NewIncluders(s *capnp.Segment) (Includers, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Includers(st), err
+}
+
+func NewRootIncluders(s *capnp.Segment) (Includers, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Includers(st), err
+}
+
+func ReadRootIncluders(msg *capnp.Message) (Includers, error) {
+	root, err := msg.Root()
+	return Includers(root.Struct()), err
+}
+
+func (s Includers) String() string {
+	str, _ := text.Marshal(0x870c91d5b9fdf1fe, capnp.Struct(s))
+	return str
+}
+
+func (s Includers) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Includers) DecodeFromPtr(p capnp.Ptr) Includers {
+	return Includers(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Includers) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Includers) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Includers) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Includers) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Includers) Includers() (Includer_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Includer_List(p.List()), err
+}
+
+func (s Includers) HasIncluders() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s Includers) SetIncluders(v Includer_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewIncluders sets the includers field to a newly
+// allocated Includer_List, preferring placement in s's segment.
+func (s Includers) NewIncluders(n int32) (Includer_List, error) {
+	l, err := NewIncluder_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Includer_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// Includers_List is a list of Includers.
+type Includers_List = capnp.StructList[Includers]
+
+// NewIncluders creates a new list of Includers.
+func NewIncluders_List(s *capnp.Segment, sz int32) (Includers_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[Includers](l), err
+}
+
+// Includers_Future is a wrapper for a Includers promised by a client call.
+type Includers_Future struct{ *capnp.Future }
+
+func (f Includers_Future) Struct() (Includers, error) {
+	p, err := f.Future.Ptr()
+	return Includers(p.Struct()), err
+}
+
+type Includer capnp.Struct
+
+// Includer_TypeID is the unique identifier for the type Includer.
+const Includer_TypeID = 0x858f101843e04bd0
+
+func NewIncluder(s *capnp.Segment) (Includer, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Includer(st), err
+}
+
+func NewRootIncluder(s *capnp.Segment) (Includer, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Includer(st), err
+}
+
+func ReadRootIncluder(msg *capnp.Message) (Includer, error) {
+	root, err := msg.Root()
+	return Includer(root.Struct()), err
+}
+
+func (s Includer) String() string {
+	str, _ := text.Marshal(0x858f101843e04bd0, capnp.Struct(s))
+	return str
+}
+
+func (s Includer) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Includer) DecodeFromPtr(p capnp.Ptr) Includer {
+	return Includer(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Includer) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Includer) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Includer) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Includer) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Includer) PubKey() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s Includer) HasPubKey() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s Includer) SetPubKey(v []byte) error {
+	return capnp.Struct(s).SetData(0, v)
+}
+
+func (s Includer) Stake() int64 {
+	return int64(capnp.Struct(s).Uint64(0))
+}
+
+func (s Includer) SetStake(v int64) {
+	capnp.Struct(s).SetUint64(0, uint64(v))
+}
+
+// Includer_List is a list of Includer.
+type Includer_List = capnp.StructList[Includer]
+
+// NewIncluder creates a new list of Includer.
+func NewIncluder_List(s *capnp.Segment, sz int32) (Includer_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[Includer](l), err
+}
+
+// Includer_Future is a wrapper for a Includer promised by a client call.
+type Includer_Future struct{ *capnp.Future }
+
+func (f Includer_Future) Struct() (Includer, error) {
+	p, err := f.Future.Ptr()
+	return Includer(p.Struct()), err
+}
+
+const schema_af7a43984936b2af = "x\xda\x94\x90\xb1J\xc3P\x18\x85\xcf\xb9I\xbc\x0eV" +
+	"r\x89`7\xc7Z\x04kT\x1c\x8a\xd0@\xa7\xd2%" +
+	"\xd77\x88m(\xa2\xa9\xb11\x88n\x0aEp\x11\xc1" +
+	"\xc1\xb7(\xb8\xf9\x16.\x8e\x82\xaf\xd0\x07\xd0\xc8\xc5\xb4" +
+	"\x82\x9b\xc3\x07\xf7\x9e\x9f\xc3\xff\xf1\xbb\x8f\x81\xedWV" +
+	"\x05\x84\xae:\x0b\xc5k\xf7\xa3]u\xef\xc7\xd05\xb2" +
+	"\x98<\xefu\x9e\xdaW\x138\x94\x80?\xbd\xa1\xe7P" +
+	"\x96\\\x00\xde5e\xf15\xfd|y{X\xba\x85\xaa" +
+	"\xfdm\xec$\xbc\xa37\xa6,i\x01\xde;%6\x8a" +
+	"~4h\x9c\xe5\xa7#'O\x1aG\xc3\xdeI\xde\x8f" +
+	"GI6\x98\xbf\xb3\xcd^\x94\x0e\xd3f\xa7\xfc#$" +
+	"C\x0a\xbdh\xd9\x80M@\xd5\x9b\xaa.\xf5\xbaE\xbd" +
+	"+H\xae\xd0\x84\xfe\xb6\xf2\xa5\xde\xb2\xa8\xf7\x05[i" +
+	"~\xd8\x8d/C\x0aV`\xe0Zv\x1e\x1d\xc7&p" +
+	"``\xc0\x7f\x9bd\x98\xb9\xd8s\x97\xca\x81RR\xbb" +
+	"?.\xc5\xac\x09ff\xd52\x18Z\xa4\xfb{[ " +
+	"\xa0\xa2\x0c\x05\xcd0\xe0\xf7\x00_\x0f^\x7f"
+
+func RegisterSchema(reg *schemas.Registry) {
+	reg.Register(&schemas.Schema{
+		String: schema_af7a43984936b2af,
+		Nodes: []uint64{
+			0x858f101843e04bd0,
+			0x870c91d5b9fdf1fe,
+		},
+		Compressed: true,
+	})
+}
@@ -0,0 +1,104 @@
+package quorum
+
+// Policy decides when certificates complete and rounds finalize.
+type Policy interface {
+	// Weight returns the weight the includer contributes to certificates it signs and rounds
+	// it produces certified messages in.
+	Weight(*Includer) int64
+	// CertificateThreshold returns the weight of signers required to complete a certificate.
+	CertificateThreshold(*Includers) int64
+	// FinalizationThreshold returns the weight of producers of completed certificates required
+	// to finalize a round.
+	FinalizationThreshold(*Includers) int64
+}
+
+// DefaultPolicy requires more than 2/3 of the stake, i.e. 2f+1, both to complete certificates
+// and to finalize rounds.
+var DefaultPolicy Policy = NewStakePolicy(TwoThirds, TwoThirds)
+
+var (
+	// OneThird is exceeded by f+1 out of 3f+1, which is enough for availability as at least
+	// one of them is honest.
+	OneThird = Fraction{Numerator: 1, Denominator: 3}
+	// TwoThirds is exceeded by 2f+1 out of 3f+1, which is enough for any two quorums to
+	// intersect in an honest one.
+	TwoThirds = Fraction{Numerator: 2, Denominator: 3}
+)
+
+// Fraction of the total weight of includers, which a threshold must exceed.
+type Fraction struct {
+	Numerator   int64
+	Denominator int64
+}
+
+// threshold returns the minimal weight exceeding the fraction of the total weight.
+func (f Fraction) threshold(total int64) int64 {
+	return total*f.Numerator/f.Denominator + 1
+}
+
+// ThresholdPolicy is a [Policy] with thresholds exceeding fractions of the total weight of
+// includers.
+type ThresholdPolicy struct {
+	// Certificate is the fraction signers of a certificate must exceed to complete it.
+	Certificate Fraction
+	// Finalization is the fraction producers of completed certificates must exceed to finalize
+	// a round.
+	Finalization Fraction
+	// Counting weights every includer equally instead of by its stake.
+	Counting bool
+}
+
+// NewStakePolicy returns a stake weighted [ThresholdPolicy].
+func NewStakePolicy(certificate, finalization Fraction) *ThresholdPolicy {
+	return &ThresholdPolicy{Certificate: certificate, Finalization: finalization}
+}
+
+// NewCountPolicy returns a [ThresholdPolicy] weighting every includer equally regardless of
+// the stake.
+func NewCountPolicy(certificate, finalization Fraction) *ThresholdPolicy {
+	return &ThresholdPolicy{Certificate: certificate, Finalization: finalization, Counting: true}
+}
+
+func (p *ThresholdPolicy) Weight(includer *Includer) int64 {
+	if p.Counting {
+		return 1
+	}
+	return includer.Stake
+}
+
+func (p *ThresholdPolicy) CertificateThreshold(includers *Includers) int64 {
+	return p.Certificate.threshold(p.totalWeight(includers))
+}
+
+func (p *ThresholdPolicy) FinalizationThreshold(includers *Includers) int64 {
+	return p.Finalization.threshold(p.totalWeight(includers))
+}
+
+func (p *ThresholdPolicy) totalWeight(includers *Includers) int64 {
+	if p.Counting {
+		return int64(includers.Len())
+	}
+	return includers.TotalStake()
+}
+
+// Option configures a [Quorum] and verification of its certificates.
+type Option func(*options)
+
+type options struct {
+	policy Policy
+}
+
+// WithPolicy sets the [Policy], which must be the same for every party of the network.
+func WithPolicy(policy Policy) Option {
+	return func(o *options) {
+		o.policy = policy
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{policy: DefaultPolicy}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
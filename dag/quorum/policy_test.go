@@ -0,0 +1,99 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/crypto/local"
+)
+
+func TestPolicyThresholds(t *testing.T) {
+	_, includers := newIncluders(t, 10)
+
+	assert.EqualValues(t, 7, DefaultPolicy.CertificateThreshold(includers))
+	assert.EqualValues(t, 7, DefaultPolicy.FinalizationThreshold(includers))
+
+	availability := NewStakePolicy(OneThird, TwoThirds)
+	assert.EqualValues(t, 4, availability.CertificateThreshold(includers))
+	assert.EqualValues(t, 7, availability.FinalizationThreshold(includers))
+}
+
+func TestQuorumPolicy(t *testing.T) {
+	privKeys, includers := newIncluders(t, 10)
+	policy := NewStakePolicy(OneThird, TwoThirds)
+
+	defaultQrm, policyQrm := NewQuorum(includers), NewQuorum(includers, WithPolicy(policy))
+	for i, privKey := range privKeys {
+		msg := newMessage(t, privKey)
+		for _, qrm := range []*Quorum{defaultQrm, policyQrm} {
+			require.NoError(t, qrm.Add(msg))
+			cert, _ := qrm.Get(msg.ID)
+			for _, signer := range privKeys[:4] {
+				_, err := cert.AddSignature(sign(t, signer, msg))
+				require.NoError(t, err)
+			}
+		}
+
+		// f+1 signatures complete certificates for availability only
+		assert.Empty(t, defaultQrm.List())
+		assert.Len(t, policyQrm.List(), i+1)
+
+		// while rounds still require 2f+1 producers of certified messages
+		finalized, err := policyQrm.Finalize()
+		require.NoError(t, err)
+		assert.Equal(t, i+1 >= 7, finalized)
+
+		if i == 0 {
+			qc, ok := policyQrm.Get(msg.ID)
+			require.True(t, ok)
+			cert := NewCertificate("test", qc)
+			assert.Error(t, Verify(cert, "test", includers, local.NewVerifier()))
+			assert.NoError(t, Verify(cert, "test", includers, local.NewVerifier(), WithPolicy(policy)))
+		}
+	}
+}
+
+func TestQuorumCountPolicy(t *testing.T) {
+	privKeys := make([]ed25519.PrivateKey, 4)
+	incls := make([]*Includer, 4)
+	for i, stake := range []int64{100, 1, 1, 1} {
+		pubKey, privKey, err := ed25519.GenKeys()
+		require.NoError(t, err)
+		privKeys[i] = privKey
+		incls[i] = NewIncluder(pubKey, stake)
+	}
+	includers := NewIncludersSet(incls)
+	whale := privKeys[0]
+
+	stakeQrm := NewQuorum(includers)
+	countQrm := NewQuorum(includers, WithPolicy(NewCountPolicy(TwoThirds, TwoThirds)))
+	msg := newMessage(t, whale)
+	for _, qrm := range []*Quorum{stakeQrm, countQrm} {
+		require.NoError(t, qrm.Add(msg))
+		cert, _ := qrm.Get(msg.ID)
+		_, err := cert.AddSignature(sign(t, whale, msg))
+		require.NoError(t, err)
+	}
+
+	// the whale alone has enough stake, but not enough count
+	assert.Len(t, stakeQrm.List(), 1)
+	assert.Empty(t, countQrm.List())
+
+	cert, _ := countQrm.Get(msg.ID)
+	for _, signer := range privKeys[1:3] {
+		_, err := cert.AddSignature(sign(t, signer, msg))
+		require.NoError(t, err)
+	}
+	assert.Len(t, countQrm.List(), 1)
+
+	// a single producer is not enough to finalize by count
+	finalized, err := countQrm.Finalize()
+	require.NoError(t, err)
+	assert.False(t, finalized)
+	finalized, err = stakeQrm.Finalize()
+	require.NoError(t, err)
+	assert.True(t, finalized)
+}
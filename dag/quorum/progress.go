@@ -0,0 +1,88 @@
+package quorum
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// Progress reports the progress of the Quorum towards finalization, listing includers whose
+// messages are absent and certificates ordered by indices of their producers.
+func (q *Quorum) Progress() rebro.QuorumProgress {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	progress := rebro.QuorumProgress{
+		Certificates: make([]rebro.CertificateProgress, 0, len(q.certificates)),
+		Weight:       q.activeWeight,
+		Required:     q.policy.FinalizationThreshold(q.includers),
+	}
+	progress.Finalized = progress.Weight >= progress.Required
+
+	// producers are always includers, as checked on addition
+	certs := make([]*certificate, 0, len(q.certificates))
+	produced := make([]byte, bitmapSize(q.includers.Len()))
+	for _, cert := range q.certificates {
+		certs = append(certs, cert)
+		bitmapSet(produced, q.includers.IndexByPubKey(cert.msg.ID.Signer()))
+	}
+	slices.SortFunc(certs, func(a, b *certificate) int {
+		ia := q.includers.IndexByPubKey(a.msg.ID.Signer())
+		ib := q.includers.IndexByPubKey(b.msg.ID.Signer())
+		if ia != ib {
+			return ia - ib
+		}
+		// equivocating producers are ordered by their messages
+		return strings.Compare(a.msg.ID.String(), b.msg.ID.String())
+	})
+	for _, cert := range certs {
+		progress.Certificates = append(progress.Certificates, q.certificateProgress(cert))
+	}
+
+	for idx := range q.includers.Len() {
+		if !bitmapHas(produced, idx) {
+			progress.Absent = append(progress.Absent, q.includers.GetByIndex(idx).PubKey.Bytes())
+		}
+	}
+	return progress
+}
+
+// CertificateProgress reports the progress of the certificate towards completion.
+func (q *Quorum) CertificateProgress(id rebro.MessageID) (rebro.CertificateProgress, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	cert, ok := q.certificates[id.String()]
+	if !ok {
+		return rebro.CertificateProgress{}, false
+	}
+	return q.certificateProgress(cert), true
+}
+
+// certificateProgress reports the progress of the certificate, listing signers and missing
+// includers in the order of their indices.
+// It expects the lock to be held.
+func (q *Quorum) certificateProgress(cert *certificate) rebro.CertificateProgress {
+	progress := rebro.CertificateProgress{
+		ID:        cert.msg.ID,
+		Weight:    cert.activeWeight,
+		Required:  q.policy.CertificateThreshold(q.includers),
+		Completed: cert.completed,
+	}
+
+	signed := make([]byte, bitmapSize(q.includers.Len()))
+	for _, sig := range cert.signatures {
+		// signers are always includers, as checked on addition
+		bitmapSet(signed, q.includers.IndexByPubKey(sig.Signer))
+	}
+	for idx := range q.includers.Len() {
+		pubKey := q.includers.GetByIndex(idx).PubKey.Bytes()
+		if bitmapHas(signed, idx) {
+			progress.Signed = append(progress.Signed, pubKey)
+		} else {
+			progress.Missing = append(progress.Missing, pubKey)
+		}
+	}
+	return progress
+}
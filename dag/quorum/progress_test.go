@@ -0,0 +1,67 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumProgress(t *testing.T) {
+	privKeys, includers := newIncluders(t, 4)
+	qrm := NewQuorum(includers)
+
+	progress := qrm.Progress()
+	assert.Empty(t, progress.Certificates)
+	assert.Len(t, progress.Absent, 4)
+	assert.EqualValues(t, 0, progress.Weight)
+	assert.EqualValues(t, 3, progress.Required)
+	assert.False(t, progress.Finalized)
+
+	// three of four propose, and only the first gets all the signatures
+	for i, privKey := range privKeys[:3] {
+		msg := newMessage(t, privKey)
+		require.NoError(t, qrm.Add(msg))
+		cert, _ := qrm.Get(msg.ID)
+		signers := privKeys[:1]
+		if i == 0 {
+			signers = privKeys
+		}
+		for _, signer := range signers {
+			_, err := cert.AddSignature(sign(t, signer, msg))
+			require.NoError(t, err)
+		}
+	}
+
+	progress = qrm.Progress()
+	assert.False(t, progress.Finalized)
+	assert.EqualValues(t, 1, progress.Weight)
+	assert.Equal(t, [][]byte{privKeys[3].PubKey().Bytes()}, progress.Absent)
+	require.Len(t, progress.Certificates, 3)
+
+	var completed int
+	for _, cert := range progress.Certificates {
+		assert.EqualValues(t, 3, cert.Required)
+		assert.EqualValues(t, len(cert.Signed), cert.Weight)
+		assert.Len(t, cert.Missing, 4-len(cert.Signed))
+		assert.Contains(t, cert.Signed, privKeys[0].PubKey().Bytes())
+		if cert.Completed {
+			completed++
+			assert.Empty(t, cert.Missing)
+			continue
+		}
+		assert.ElementsMatch(t, [][]byte{
+			privKeys[1].PubKey().Bytes(),
+			privKeys[2].PubKey().Bytes(),
+			privKeys[3].PubKey().Bytes(),
+		}, cert.Missing)
+
+		byID, ok := qrm.CertificateProgress(cert.ID)
+		require.True(t, ok)
+		assert.Equal(t, cert, byID)
+	}
+	assert.Equal(t, 1, completed)
+
+	_, ok := qrm.CertificateProgress(newMessage(t, privKeys[3]).ID)
+	assert.False(t, ok)
+}
@@ -3,31 +3,36 @@ package quorum
 import (
 	"bytes"
 	"errors"
+	"sync"
 
 	"github.com/iykyk-syn/unison/crypto"
 	"github.com/iykyk-syn/unison/rebro"
 )
 
-var (
-	faultDenominator int64 = 3
-	faultNumerator   int64 = 2
-)
-
+// Quorum is a [rebro.QuorumCertificate] completing certificates and finalizing rounds by
+// the [Policy], which defaults to [DefaultPolicy].
+// It is safe for concurrent use, so it can be read while broadcasting keeps adding late signatures.
 type Quorum struct {
 	includers *Includers
+	policy    Policy
 
+	mu           sync.RWMutex
 	certificates map[string]*certificate
-	activeStake  int64
+	activeWeight int64
 }
 
-func NewQuorum(includers *Includers) *Quorum {
+func NewQuorum(includers *Includers, opts ...Option) *Quorum {
 	return &Quorum{
 		includers:    includers,
+		policy:       newOptions(opts).policy,
 		certificates: make(map[string]*certificate, includers.Len()),
 	}
 }
 
 func (q *Quorum) Add(msg rebro.Message) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
 	signer := q.includers.GetByPubKey(msg.ID.Signer())
 	if signer == nil {
 		return errors.New("signer is not a part of the includers set")
@@ -46,12 +51,23 @@ func (q *Quorum) Add(msg rebro.Message) error {
 	return err
 }
 
+// IsIncluder reports whether the identity is a part of the includers set.
+func (q *Quorum) IsIncluder(signer []byte) bool {
+	return q.includers.GetByPubKey(signer) != nil
+}
+
 func (q *Quorum) Get(id rebro.MessageID) (rebro.Certificate, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
 	com, ok := q.certificates[id.String()]
 	return com, ok
 }
 
 func (q *Quorum) Delete(id rebro.MessageID) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
 	if _, ok := q.certificates[id.String()]; !ok {
 		return false
 	}
@@ -60,6 +76,9 @@ func (q *Quorum) Delete(id rebro.MessageID) bool {
 }
 
 func (q *Quorum) List() []rebro.Certificate {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
 	comms := make([]rebro.Certificate, 0, len(q.certificates))
 	for _, comm := range q.certificates {
 		if comm.completed {
@@ -70,7 +89,10 @@ func (q *Quorum) List() []rebro.Certificate {
 }
 
 func (q *Quorum) Finalize() (bool, error) {
-	finalized := q.activeStake >= q.stakeRequired()
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	finalized := q.activeWeight >= q.policy.FinalizationThreshold(q.includers)
 	return finalized, nil
 }
 
@@ -83,6 +105,9 @@ func (q *Quorum) newCertificate(msg rebro.Message) (*certificate, error) {
 }
 
 func (q *Quorum) addSignature(s crypto.Signature, cert *certificate) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
 	signer := q.includers.GetByPubKey(s.Signer)
 	if signer == nil {
 		return false, errors.New("the signer is not a part of includers set")
@@ -95,23 +120,19 @@ func (q *Quorum) addSignature(s crypto.Signature, cert *certificate) (bool, erro
 	}
 
 	cert.signatures = append(cert.signatures, s)
-	cert.activeStake = safeAddClip(cert.activeStake, signer.Stake)
+	cert.activeWeight = safeAddClip(cert.activeWeight, q.policy.Weight(signer))
 	if cert.completed {
 		// terminate if the certificate was already completed
 		return true, nil
 	}
 
-	cert.completed = cert.activeStake >= q.stakeRequired()
+	cert.completed = cert.activeWeight >= q.policy.CertificateThreshold(q.includers)
 	if !cert.completed {
 		// terminate if the certificate is still not completed
 		return false, nil
 	}
-	// if it is - update the stake
+	// if it is - update the weight
 	includer := q.includers.GetByPubKey(cert.msg.ID.Signer())
-	q.activeStake = safeAddClip(q.activeStake, includer.Stake)
+	q.activeWeight = safeAddClip(q.activeWeight, q.policy.Weight(includer))
 	return true, nil
 }
-
-func (q *Quorum) stakeRequired() int64 {
-	return q.includers.TotalStake()*faultNumerator/faultDenominator + 1
-}
@@ -2,10 +2,15 @@ package rebro
 
 import (
 	"context"
+	"errors"
 
 	"github.com/iykyk-syn/unison/crypto"
 )
 
+// ErrRoundInterrupted is returned by Broadcaster when broadcasting is interrupted before
+// finalization, e.g. by context cancellation or a network failure.
+var ErrRoundInterrupted = errors.New("broadcasting round interrupted")
+
 // Broadcaster reliably broadcasts, delivers and commits over messages. It verifies Messages
 // delivered from other quorum participants and accumulates them into QuorumCertificate until its
 // finalized.
@@ -25,9 +30,23 @@ type Broadcaster interface {
 	// until QuorumCertificate is finalized.
 	// Broadcast takes full ownership over QuorumCertificate, and it must not be modified until
 	// Broadcast finishes execution.
+	// Broadcaster may keep collecting late signatures and data into QuorumCertificate for some time
+	// after Broadcast finishes, so the QuorumCertificate must remain safe for concurrent reads.
+	//
+	// If broadcasting is interrupted before finalization, Broadcast returns ErrRoundInterrupted and
+	// stops modifying QuorumCertificate, leaving its partial state to the caller. The round can then be
+	// resumed by broadcasting the same Message with the same QuorumCertificate again.
 	Broadcast(context.Context, Message, QuorumCertificate) error
 }
 
+// AsyncBroadcaster is a Broadcaster allowing to track broadcasting progress.
+type AsyncBroadcaster interface {
+	Broadcaster
+	// BroadcastAsync starts broadcasting the same way as Broadcast does, but without waiting for
+	// finalization. The progress is tracked over the returned BroadcastHandle.
+	BroadcastAsync(context.Context, Message, QuorumCertificate) (BroadcastHandle, error)
+}
+
 // Certifier performs application-specific stateful certification of messages.
 // It used by Broadcaster during broadcasting rounds.
 type Certifier interface {
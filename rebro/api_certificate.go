@@ -22,6 +22,9 @@ type Certificate interface {
 // QuorumCertificate is mutable and append-only until its finalized.
 // It expects arbitrary number of new Certificates to be added until finalization is triggered.
 // The finalization conditions and quorums are implementation specific.
+//
+// QuorumCertificate may still be written by Broadcaster after finalization, while being read by
+// the caller, so implementations must be safe for concurrent use.
 type QuorumCertificate interface {
 	// Add constructs new Certificate from given the given message and adds it to the set
 	// performing necessary verification.
@@ -38,3 +41,10 @@ type QuorumCertificate interface {
 	// It may additionally perform expensive computation, like signature aggregation.
 	Finalize() (bool, error)
 }
+
+// IncluderChecker is an optional interface of QuorumCertificate telling apart its participants,
+// so that gossips of strangers are dropped before any resources are held for them.
+type IncluderChecker interface {
+	// IsIncluder reports whether the identity participates in the QuorumCertificate.
+	IsIncluder(signer []byte) bool
+}
@@ -0,0 +1,64 @@
+package rebro
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iykyk-syn/unison/crypto"
+)
+
+// ErrNotCertified is returned when broadcasting finishes without the broadcasted Message being certified.
+var ErrNotCertified = errors.New("message not certified")
+
+// EventKind defines kinds of broadcasting progress Events.
+type EventKind uint8
+
+const (
+	// EventCertificateAdded signals a new Certificate was added to QuorumCertificate.
+	EventCertificateAdded EventKind = iota
+	// EventSignatureAdded signals a new signature was added to one of the Certificates.
+	EventSignatureAdded
+	// EventCertified signals one of the Certificates has collected enough signatures.
+	EventCertified
+	// EventFinalized signals QuorumCertificate has been finalized.
+	EventFinalized
+)
+
+// String returns string representation of EventKind.
+func (k EventKind) String() string {
+	switch k {
+	case EventCertificateAdded:
+		return "certificate_added"
+	case EventSignatureAdded:
+		return "signature_added"
+	case EventCertified:
+		return "certified"
+	case EventFinalized:
+		return "finalized"
+	default:
+		return "unknown"
+	}
+}
+
+// Event reports progress of a broadcasting round.
+type Event struct {
+	// Kind of the Event.
+	Kind EventKind
+	// ID of the Message the Event relates to. Nil for EventFinalized.
+	ID MessageID
+	// Signature added to the Certificate. Set for EventSignatureAdded only.
+	Signature crypto.Signature
+}
+
+// BroadcastHandle tracks progress of the asynchronous broadcasting.
+type BroadcastHandle interface {
+	// Events streams progress Events of the broadcasting round.
+	// The channel is closed once broadcasting finishes. Events are dropped, if not consumed in time.
+	Events() <-chan Event
+	// AwaitCertified awaits until the broadcasted Message collects enough signatures.
+	// It returns ErrNotCertified if broadcasting finishes before that.
+	AwaitCertified(context.Context) error
+	// AwaitFinalized awaits until QuorumCertificate is finalized and broadcasting finishes,
+	// reporting its outcome.
+	AwaitFinalized(context.Context) error
+}
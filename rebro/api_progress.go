@@ -0,0 +1,43 @@
+package rebro
+
+import "errors"
+
+// ErrProgressUnsupported is returned when QuorumCertificate does not report its progress.
+var ErrProgressUnsupported = errors.New("quorum certificate does not report progress")
+
+// ProgressReporter is an optional interface of QuorumCertificate reporting its progress, so that
+// hanging rounds can be inspected.
+type ProgressReporter interface {
+	// Progress reports the progress of the QuorumCertificate towards finalization.
+	Progress() QuorumProgress
+}
+
+// QuorumProgress is a snapshot of QuorumCertificate progress towards finalization.
+type QuorumProgress struct {
+	// Certificates reports progress of every Certificate in the QuorumCertificate.
+	Certificates []CertificateProgress
+	// Weight is the weight of the producers of completed Certificates.
+	Weight int64
+	// Required is the weight required for finalization.
+	Required int64
+	// Absent lists identities of the quorum participants without Certificates of their messages.
+	Absent [][]byte
+	// Finalized tells whether the finalization conditions are met.
+	Finalized bool
+}
+
+// CertificateProgress is a snapshot of Certificate progress towards completion.
+type CertificateProgress struct {
+	// ID is the MessageID the Certificate attests to.
+	ID MessageID
+	// Weight is the weight of the signers collected so far.
+	Weight int64
+	// Required is the weight required for completion.
+	Required int64
+	// Signed lists identities of the signers collected so far.
+	Signed [][]byte
+	// Missing lists identities of the quorum participants yet to sign.
+	Missing [][]byte
+	// Completed tells whether enough signatures are collected.
+	Completed bool
+}
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"runtime/debug"
+	"slices"
+	"sync"
 	"time"
 
 	"capnproto.org/go/capnp/v3"
@@ -25,14 +28,33 @@ type Broadcaster struct {
 
 	rounds *round.Manager
 	pubsub *pubsub.PubSub
+	// topic of the version gossips are published with
 	topic  *pubsub.Topic
-	sub    *pubsub.Subscription
+	topics map[Version]*pubsub.Topic
+	subs   []*pubsub.Subscription
 
+	verifier crypto.Verifier
+	// signer is nil in the observer mode
 	signer    crypto.Signer
 	certifier rebro.Certifier
 	hasher    rebro.Hasher
 	decoder   rebro.MessageIDDecoder
 
+	params    Parameters
+	queue     *queue
+	assembler *assembler
+	bundler   *bundler
+	throttle  *throttle
+	parked    *parked
+	metrics   *metrics
+
+	// finalized rounds awaiting the next one to stop
+	lingeringMu sync.Mutex
+	lingering   []uint64
+
+	// set in the observer mode only
+	observing *observing
+
 	log *slog.Logger
 }
 
@@ -44,95 +66,381 @@ func NewBroadcaster(
 	hasher rebro.Hasher,
 	decoder rebro.MessageIDDecoder,
 	ps *pubsub.PubSub,
+	opts ...Option,
+) *Broadcaster {
+	return newBroadcaster(networkID, singer, singer, certifier, hasher, decoder, ps, opts...)
+}
+
+func newBroadcaster(
+	networkID rebro.NetworkID,
+	verifier crypto.Verifier,
+	singer crypto.Signer,
+	certifier rebro.Certifier,
+	hasher rebro.Hasher,
+	decoder rebro.MessageIDDecoder,
+	ps *pubsub.PubSub,
+	opts ...Option,
 ) *Broadcaster {
-	return &Broadcaster{
+	params := DefaultParameters()
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	metrics := &metrics{}
+	bro := &Broadcaster{
 		networkID: networkID,
-		rounds:    round.NewManager(),
+		rounds:    round.NewManager(params.RoundsAhead, params.MaxRoundSubscriptions),
 		pubsub:    ps,
+		verifier:  verifier,
 		signer:    singer,
 		certifier: certifier,
 		hasher:    hasher,
 		decoder:   decoder,
+		params:    params,
+		queue:     newQueue(),
+		assembler: newAssembler(),
+		throttle:  newThrottle(params),
+		parked:    newParked(),
+		metrics:   metrics,
 		log:       slog.With("module", "broadcaster"),
 	}
+	bro.bundler = newBundler(params, bro.publishSignatures)
+	return bro
 }
 
 func (bro *Broadcaster) Start() (err error) {
-	// TODO(@Wondartan): versioning for topic
-	bro.topic, err = bro.pubsub.Join(bro.networkID.String())
+	if len(bro.params.Versions) == 0 {
+		return errors.New("no wire protocol versions")
+	}
+
+	bro.topics = make(map[Version]*pubsub.Topic, len(bro.params.Versions))
+	for _, version := range bro.params.Versions {
+		if !version.Supported() {
+			return fmt.Errorf("unsupported wire protocol version %s", version)
+		}
+		if _, ok := bro.topics[version]; ok {
+			return fmt.Errorf("duplicate wire protocol version %s", version)
+		}
+
+		err = bro.join(version)
+		if err != nil {
+			return fmt.Errorf("joining wire protocol version %s: %w", version, err)
+		}
+	}
+	bro.topic = bro.topics[bro.params.Versions[0]]
+
+	bro.log.Debug("started", "versions", bro.params.Versions)
+	return nil
+}
+
+// join joins the topic of the wire protocol version and starts validating its gossips.
+func (bro *Broadcaster) join(version Version) error {
+	topicName := TopicName(bro.networkID, version)
+	topic, err := bro.pubsub.Join(topicName)
 	if err != nil {
 		return err
 	}
+	bro.topics[version] = topic
 
 	// pubsub forces us to create at least one subscription
-	bro.sub, err = bro.topic.Subscribe()
+	sub, err := topic.Subscribe()
 	if err != nil {
 		return err
 	}
+	bro.subs = append(bro.subs, sub)
 	go func() {
 		for {
-			_, err := bro.sub.Next(context.Background())
+			_, err := sub.Next(context.Background())
 			if err != nil {
 				return
 			}
 		}
 	}()
 
-	err = bro.pubsub.RegisterTopicValidator(
-		bro.networkID.String(),
-		bro.deliverGossip,
+	return bro.pubsub.RegisterTopicValidator(
+		topicName,
+		func(ctx context.Context, from peer.ID, gossip *pubsub.Message) pubsub.ValidationResult {
+			return bro.deliverGossip(ctx, version, from, gossip)
+		},
 		pubsub.WithValidatorTimeout(ValidationTimeout),
 	)
-	if err != nil {
-		return err
-	}
-
-	bro.log.Debug("started")
-	return nil
 }
 
 func (bro *Broadcaster) Stop(ctx context.Context) (err error) {
-	bro.sub.Cancel()
-	err = errors.Join(err, bro.topic.Close())
-	err = errors.Join(err, bro.pubsub.UnregisterTopicValidator(bro.networkID.String()))
+	bro.bundler.stop()
+	for _, sub := range bro.subs {
+		sub.Cancel()
+	}
+	for version, topic := range bro.topics {
+		err = errors.Join(err, topic.Close())
+		err = errors.Join(err, bro.pubsub.UnregisterTopicValidator(TopicName(bro.networkID, version)))
+	}
+	err = errors.Join(err, bro.queue.stop(ctx))
+	if bro.observing != nil {
+		// observed rounds may never finalize, so they are not awaited
+		err = errors.Join(err, bro.stopObserved(ctx, math.MaxUint64))
+	}
 	err = errors.Join(err, bro.rounds.Stop(ctx))
 	return err
 }
 
+// Metrics reports gossip processing statistics.
+func (bro *Broadcaster) Metrics() Metrics {
+	return bro.metrics.snapshot()
+}
+
 func (bro *Broadcaster) Broadcast(ctx context.Context, msg rebro.Message, qcomm rebro.QuorumCertificate) error {
-	r, err := bro.rounds.StartRound(msg.ID.Round(), qcomm)
+	h, err := bro.broadcastAsync(ctx, msg, qcomm)
 	if err != nil {
 		return err
 	}
+	// the round gets interrupted on context cancellation, so wait for it to be cleaned up
+	<-h.doneCh
+	return h.err
+}
 
-	err = bro.broadcastGossip(ctx, func(message gossipmsg.Gossip) error {
-		canonicalID, err := msg.ID.MarshalBinary()
+// BroadcastAsync starts broadcasting the message and returns [rebro.BroadcastHandle] tracking
+// the progress of the round until it is finalized.
+// Broadcasting the same message and [rebro.QuorumCertificate] of the interrupted round resumes it.
+func (bro *Broadcaster) BroadcastAsync(
+	ctx context.Context,
+	msg rebro.Message,
+	qcomm rebro.QuorumCertificate,
+) (rebro.BroadcastHandle, error) {
+	h, err := bro.broadcastAsync(ctx, msg, qcomm)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (bro *Broadcaster) broadcastAsync(
+	ctx context.Context,
+	msg rebro.Message,
+	qcomm rebro.QuorumCertificate,
+) (*handle, error) {
+	// the message of the resumed round has already been published
+	_, published := qcomm.Get(msg.ID)
+
+	h := newHandle(msg.ID)
+	r, err := bro.rounds.StartRound(msg.ID.Round(), qcomm, h.observe)
+	if err != nil {
+		return nil, err
+	}
+	bro.stopLingering(ctx, msg.ID.Round())
+	// older interrupted rounds cannot be resumed anymore, while the resumed one gets gossips
+	// received in the meantime
+	bro.parked.drop(msg.ID.Round())
+	bro.replayParked(msg.ID.Round())
+
+	if !published {
+		err = bro.publishData(ctx, msg)
 		if err != nil {
-			return err
+			return nil, bro.interruptRound(msg.ID.Round(), err)
 		}
+	}
 
-		if err = message.SetId(canonicalID); err != nil {
-			return err
+	go func() {
+		err := r.Finalize(ctx)
+		if err != nil {
+			h.finish(bro.interruptRound(msg.ID.Round(), err))
+			return
 		}
+		h.finish(bro.finishRound(ctx, msg.ID.Round()))
+	}()
+	return h, nil
+}
 
-		if err = message.Data().SetData(msg.Data); err != nil {
-			return err
-		}
+// Progress reports the progress of the running round towards finalization, so that hanging rounds
+// can be inspected. The round's [rebro.QuorumCertificate] must implement [rebro.ProgressReporter].
+func (bro *Broadcaster) Progress(ctx context.Context, roundNum uint64) (rebro.QuorumProgress, error) {
+	r, ok := bro.rounds.LookupRound(roundNum)
+	if !ok {
+		return rebro.QuorumProgress{}, fmt.Errorf("round %d is not running", roundNum)
+	}
+	return r.Progress(ctx)
+}
 
-		message.SetData()
-		return nil
-	})
+// AbandonRound forgets the interrupted round, so that it cannot be resumed anymore.
+func (bro *Broadcaster) AbandonRound(roundNum uint64) error {
+	err := bro.rounds.AbandonRound(roundNum)
 	if err != nil {
 		return err
 	}
+	bro.parked.take(roundNum)
+	return nil
+}
+
+// interruptRound stops the unfinalized round keeping it resumable.
+func (bro *Broadcaster) interruptRound(roundNum uint64, cause error) error {
+	// the broadcasting context is likely done already, so use a fresh one
+	ctx, cancel := context.WithTimeout(context.Background(), bro.params.ProcessingTimeout)
+	defer cancel()
+
+	err := bro.rounds.InterruptRound(ctx, roundNum)
+	bro.queue.drop(roundNum)
+	bro.assembler.drop(roundNum)
+	if err != nil {
+		err = fmt.Errorf("stopping round(%d): %w", roundNum, err)
+	}
+	return errors.Join(fmt.Errorf("%w(%d): %w", rebro.ErrRoundInterrupted, roundNum, cause), err)
+}
+
+// finishRound stops the finalized round according to the configured policy,
+// letting it collect late signatures and data in the meantime.
+func (bro *Broadcaster) finishRound(ctx context.Context, roundNum uint64) error {
+	if bro.params.RoundGracePeriod == 0 && !bro.params.StopOnNextRound {
+		return bro.stopRound(ctx, roundNum)
+	}
+
+	if bro.params.StopOnNextRound {
+		bro.lingeringMu.Lock()
+		bro.lingering = append(bro.lingering, roundNum)
+		bro.lingeringMu.Unlock()
+	}
+
+	if bro.params.RoundGracePeriod > 0 {
+		time.AfterFunc(bro.params.RoundGracePeriod, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), bro.params.ProcessingTimeout)
+			defer cancel()
+
+			err := bro.stopRound(ctx, roundNum)
+			if err != nil && !errors.Is(err, round.ErrElapsedRound) {
+				bro.log.ErrorContext(ctx, "stopping round after grace period", "round", roundNum, "err", err)
+			}
+		})
+	}
+	return nil
+}
+
+// stopLingering stops finalized rounds preceding the given one.
+func (bro *Broadcaster) stopLingering(ctx context.Context, roundNum uint64) {
+	bro.lingeringMu.Lock()
+	var stop []uint64
+	bro.lingering = slices.DeleteFunc(bro.lingering, func(lingering uint64) bool {
+		if lingering < roundNum {
+			stop = append(stop, lingering)
+			return true
+		}
+		return false
+	})
+	bro.lingeringMu.Unlock()
+
+	for _, lingering := range stop {
+		err := bro.stopRound(ctx, lingering)
+		if err != nil && !errors.Is(err, round.ErrElapsedRound) {
+			bro.log.ErrorContext(ctx, "stopping round on the next one", "round", lingering, "err", err)
+		}
+	}
+}
 
-	err = r.Finalize(ctx)
+// stopRound stops the round and drops all its in-progress gossips.
+func (bro *Broadcaster) stopRound(ctx context.Context, roundNum uint64) error {
+	err := bro.rounds.StopRound(ctx, roundNum)
+	bro.queue.drop(roundNum)
+	bro.assembler.drop(roundNum)
+	return err
+}
+
+// publishData publishes the data gossip of the message.
+// Data exceeding [Parameters.ChunkSize] is published in chunks.
+func (bro *Broadcaster) publishData(ctx context.Context, msg rebro.Message) error {
+	canonicalID, err := msg.ID.MarshalBinary()
 	if err != nil {
 		return err
 	}
 
-	// TODO: Delayed stopped to collect more signatures
-	return bro.rounds.StopRound(ctx, msg.ID.Round())
+	if len(msg.Data) <= bro.params.ChunkSize {
+		return bro.broadcastGossip(ctx, func(message gossipmsg.Gossip) error {
+			if err := message.SetId(canonicalID); err != nil {
+				return err
+			}
+
+			if err := message.Data().SetData(msg.Data); err != nil {
+				return err
+			}
+
+			message.SetData()
+			return nil
+		})
+	}
+
+	parts := splitData(msg.Data, bro.params.ChunkSize)
+	if len(parts) > bro.params.MaxChunks {
+		return fmt.Errorf("message data of %d bytes exceeds %d chunks", len(msg.Data), bro.params.MaxChunks)
+	}
+
+	root, proofs := merkleTree(parts)
+	domain := chunksDomain(bro.networkID, bro.params.Versions[0])
+	signature, err := bro.signer.Sign(domain.Tag(chunksCommitment(canonicalID, uint32(len(parts)), root)))
+	if err != nil {
+		return fmt.Errorf("signing chunks commitment: %w", err)
+	}
+	for i, part := range parts {
+		err = bro.broadcastGossip(ctx, func(message gossipmsg.Gossip) error {
+			if err := message.SetId(canonicalID); err != nil {
+				return err
+			}
+
+			message.SetChunk()
+			chunk := message.Chunk()
+			chunk.SetIndex(uint32(i))
+			chunk.SetTotal(uint32(len(parts)))
+			if err := chunk.SetRoot(root); err != nil {
+				return err
+			}
+			if err := chunk.SetSignature(signature.Body); err != nil {
+				return err
+			}
+			proof, err := chunk.NewProof(int32(len(proofs[i])))
+			if err != nil {
+				return err
+			}
+			for j, hash := range proofs[i] {
+				if err := proof.Set(j, hash); err != nil {
+					return err
+				}
+			}
+			return chunk.SetData(part)
+		})
+		if err != nil {
+			return fmt.Errorf("publishing chunk %d/%d: %w", i, len(parts), err)
+		}
+	}
+	return nil
+}
+
+// publishSignatures publishes the signatures in a single gossip.
+func (bro *Broadcaster) publishSignatures(ctx context.Context, entries []bundleEntry) error {
+	return bro.broadcastGossip(ctx, func(message gossipmsg.Gossip) error {
+		if len(entries) == 1 {
+			message.SetSignature()
+			if err := message.SetId(entries[0].canonicalID); err != nil {
+				return err
+			}
+			if err := message.Signature().SetSignature(entries[0].signature.Body); err != nil {
+				return err
+			}
+			return message.Signature().SetSigner(entries[0].signature.Signer)
+		}
+
+		list, err := message.NewSignatures(int32(len(entries)))
+		if err != nil {
+			return err
+		}
+		for i, entry := range entries {
+			if err := list.At(i).SetId(entry.canonicalID); err != nil {
+				return err
+			}
+			if err := list.At(i).SetSignature(entry.signature.Body); err != nil {
+				return err
+			}
+			if err := list.At(i).SetSigner(entry.signature.Signer); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // broadcastGossip prepares and publishes a gossip to the network.
@@ -150,6 +458,7 @@ func (bro *Broadcaster) broadcastGossip(ctx context.Context, setter func(gossipm
 	if err = setter(msg); err != nil {
 		return err
 	}
+	msg.SetVersion(uint16(bro.params.Versions[0]))
 
 	bytes, err := msgMsg.Marshal()
 	if err != nil {
@@ -164,18 +473,20 @@ func (bro *Broadcaster) broadcastGossip(ctx context.Context, setter func(gossipm
 	return nil
 }
 
-// deliverGossip delivers a PubSub gossip and reports its validity status
+// deliverGossip validates a PubSub gossip received on the topic of the given version and reports
+// its validity status. It only performs cheap structural and signature checks and hands the gossip over for
+// asynchronous processing, so that slow processing never stalls the PubSub validation pipeline.
 func (bro *Broadcaster) deliverGossip(
 	ctx context.Context,
-	_ peer.ID,
+	version Version,
+	from peer.ID,
 	gossip *pubsub.Message,
 ) (res pubsub.ValidationResult) {
 	defer func() {
 		// recover from potential panics caused by network gossips
 		err := recover()
 		if err != nil {
-			bro.log.ErrorContext(ctx, "deliver gossip panic", "err", err)
-			fmt.Println(string(debug.Stack()))
+			bro.log.ErrorContext(ctx, "deliver gossip panic", "err", err, "stack", string(debug.Stack()))
 			res = pubsub.ValidationReject
 		}
 	}()
@@ -192,11 +503,98 @@ func (bro *Broadcaster) deliverGossip(
 		return pubsub.ValidationReject
 	}
 
-	err = bro.processGossip(ctx, msg)
+	if Version(msg.Version()) != version {
+		bro.metrics.unknownVersion.Add(1)
+		bro.log.ErrorContext(ctx, "unknown wire protocol version",
+			"version", Version(msg.Version()), "topic_version", version, "from", from)
+		return pubsub.ValidationReject
+	}
+
+	gsps, err := bro.verifyGossip(msg)
+	if errors.Is(err, errFutureRound) {
+		// the node may be behind the network, so the peer is not penalized
+		bro.log.DebugContext(ctx, "ignoring gossip", "from", from, "err", err)
+		return pubsub.ValidationIgnore
+	}
 	if err != nil {
-		bro.log.ErrorContext(ctx, "processing gossip", "err", err)
+		bro.log.ErrorContext(ctx, "verifying gossip", "err", err)
 		return pubsub.ValidationReject
 	}
 
+	// bundled signatures are processed independently
+	for _, gsp := range gsps {
+		gsp.from, gsp.local = from, gossip.Local
+		bro.dispatchGossip(ctx, gsp)
+	}
 	return pubsub.ValidationAccept
 }
+
+// dispatchGossip hands the verified gossip over for asynchronous processing.
+// Network gossips are admitted by the quota of their peer first, so that no processing routine
+// is spawned for gossips over the quota.
+func (bro *Broadcaster) dispatchGossip(ctx context.Context, gsp *verifiedGossip) {
+	if !gsp.local {
+		if err := bro.throttle.admit(gsp.from, bro.metrics); err != nil {
+			bro.log.DebugContext(ctx, "dropping gossip", "from", gsp.from, "err", err)
+			return
+		}
+	}
+
+	ok := bro.queue.enqueue(gsp.id.Round(), func(ctx context.Context) {
+		bro.handleGossip(ctx, gsp)
+	})
+	if !ok && !gsp.local {
+		bro.throttle.dismiss(gsp.from)
+	}
+}
+
+// handleGossip processes the verified gossip and reports the outcome.
+func (bro *Broadcaster) handleGossip(ctx context.Context, gsp *verifiedGossip) {
+	from, local := gsp.from, gsp.local
+	defer func() {
+		// recover from potential panics caused by network gossips
+		err := recover()
+		if err != nil {
+			bro.log.ErrorContext(ctx, "handle gossip panic", "err", err, "stack", string(debug.Stack()))
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, bro.params.ProcessingTimeout)
+	defer cancel()
+
+	// local gossips are never throttled, as we publish them while processing network gossips,
+	// while network ones are admitted on dispatch already
+	if !local {
+		prio := priorityData
+		if gsp.signature != nil {
+			prio = prioritySignature
+		}
+
+		release, err := bro.throttle.acquire(ctx, from, prio, bro.metrics)
+		if err != nil {
+			bro.log.DebugContext(ctx, "dropping gossip", "from", from, "err", err)
+			return
+		}
+		defer release()
+	}
+
+	err := bro.processGossip(ctx, gsp)
+	switch {
+	case err == nil:
+		bro.metrics.processed.Add(1)
+		if !local {
+			bro.params.Scorer.record(from, true)
+		}
+	case errors.Is(err, context.Canceled):
+		// the round was dropped, so the outcome does not matter anymore
+		bro.log.DebugContext(ctx, "processing gossip canceled", "err", err)
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil:
+		bro.metrics.expired.Add(1)
+		bro.log.DebugContext(ctx, "processing gossip expired", "err", err)
+	default:
+		bro.log.ErrorContext(ctx, "processing gossip", "err", err)
+		if !local {
+			bro.params.Scorer.record(from, false)
+		}
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
+	"capnproto.org/go/capnp/v3"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/host"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
@@ -20,7 +23,11 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	crypto2 "github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/bls"
+	dagquorum "github.com/iykyk-syn/unison/dag/quorum"
 	"github.com/iykyk-syn/unison/rebro"
+	"github.com/iykyk-syn/unison/rebro/gossip/gossipmsg"
+	"github.com/iykyk-syn/unison/rebro/gossip/internal/round"
 )
 
 func TestBroadcaster(t *testing.T) {
@@ -67,13 +74,504 @@ func TestBroadcaster(t *testing.T) {
 	}
 }
 
-func broadcasterGood(t *testing.T, host host.Host) *Broadcaster {
-	psub, err := pubsub.NewGossipSub(context.Background(), host, pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign))
+func TestBroadcasterAsync(t *testing.T) {
+	const nodeCount = 10
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount)
+	require.NoError(t, err)
+
+	signers, includers := newIncluders(t, nodeCount, 1)
+	bros := make([]*Broadcaster, nodeCount)
+	for i, h := range net.Hosts() {
+		psub := newPubSub(ctx, t, h)
+		bros[i] = NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID, psub)
+	}
+
+	connect(ctx, t, net)
+	start(t, bros)
+
+	wg, wgCtx := errgroup.WithContext(ctx)
+	for _, bro := range bros {
+		wg.Go(func() error {
+			msg, err := testMessage(1, bro.signer.ID(), randData(1024))
+			if err != nil {
+				return err
+			}
+
+			h, err := bro.BroadcastAsync(wgCtx, msg, dagquorum.NewQuorum(includers))
+			if err != nil {
+				return err
+			}
+
+			kinds := make(map[rebro.EventKind]int)
+			var certified bool
+			for ev := range h.Events() {
+				kinds[ev.Kind]++
+				if ev.Kind == rebro.EventCertified && ev.ID.String() == msg.ID.String() {
+					certified = true
+				}
+			}
+			assert.Equal(t, 1, kinds[rebro.EventFinalized])
+			assert.GreaterOrEqual(t, kinds[rebro.EventCertified], nodeCount*2/3+1)
+			assert.GreaterOrEqual(t, kinds[rebro.EventCertificateAdded], kinds[rebro.EventCertified])
+			assert.GreaterOrEqual(t, kinds[rebro.EventSignatureAdded], kinds[rebro.EventCertified])
+
+			err = h.AwaitFinalized(wgCtx)
+			if err != nil {
+				return err
+			}
+			err = h.AwaitCertified(wgCtx)
+			if !certified {
+				assert.ErrorIs(t, err, rebro.ErrNotCertified)
+				return nil
+			}
+			return err
+		})
+	}
+	require.NoError(t, wg.Wait())
+}
+
+func TestBroadcasterSchemes(t *testing.T) {
+	const nodeCount = 6
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount)
+	require.NoError(t, err)
+
+	// nodes of different schemes are identified by self-describing keys, while the includers set
+	// holds raw ones
+	signers, includers := newDescribedIncluders(t, nodeCount, 1)
+	bros := make([]*Broadcaster, nodeCount)
+	for i, h := range net.Hosts() {
+		psub := newPubSub(ctx, t, h)
+		bros[i] = NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID, psub)
+	}
+
+	connect(ctx, t, net)
+	start(t, bros)
+
+	wg, wgCtx := errgroup.WithContext(ctx)
+	for _, bro := range bros {
+		wg.Go(func() error {
+			msg, err := testMessage(1, bro.signer.ID(), randData(1024))
+			if err != nil {
+				return err
+			}
+
+			qrm := dagquorum.NewQuorum(includers)
+			if err = bro.Broadcast(wgCtx, msg, qrm); err != nil {
+				return err
+			}
+			cert, ok := qrm.Get(msg.ID)
+			if !ok {
+				return fmt.Errorf("own message is missing")
+			}
+			for _, sig := range cert.Signatures() {
+				assert.NotNil(t, includers.GetByPubKey(sig.Signer))
+			}
+			return nil
+		})
+	}
+	require.NoError(t, wg.Wait())
+}
+
+func TestBroadcasterChunks(t *testing.T) {
+	const nodeCount = 10
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount)
 	require.NoError(t, err)
+
+	signers, includers := newIncluders(t, nodeCount, 1)
+	bros := make([]*Broadcaster, nodeCount)
+	for i, h := range net.Hosts() {
+		psub := newPubSub(ctx, t, h)
+		bros[i] = NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID, psub,
+			WithChunking(100, 16))
+	}
+
+	connect(ctx, t, net)
+	start(t, bros)
+
+	data := make(map[string][]byte, nodeCount)
+	msgs := make([]rebro.Message, nodeCount)
+	for i, bro := range bros {
+		msgs[i], err = testMessage(1, bro.signer.ID(), randData(1000+i))
+		require.NoError(t, err)
+		data[msgs[i].ID.String()] = msgs[i].Data
+	}
+
+	wg, wgCtx := errgroup.WithContext(ctx)
+	for i, bro := range bros {
+		wg.Go(func() error {
+			qrm := dagquorum.NewQuorum(includers)
+			err := bro.Broadcast(wgCtx, msgs[i], qrm)
+			if err != nil {
+				return err
+			}
+
+			assertSafe(t, 1, includers, qrm)
+			for _, cert := range qrm.List() {
+				assert.Equal(t, data[cert.Message().ID.String()], cert.Message().Data)
+			}
+			return nil
+		})
+	}
+	require.NoError(t, wg.Wait())
+
+	// data over the chunks limit is not published
+	msg, err := testMessage(2, bros[0].signer.ID(), randData(1601))
+	require.NoError(t, err)
+	err = bros[0].Broadcast(ctx, msg, dagquorum.NewQuorum(includers))
+	assert.ErrorIs(t, err, rebro.ErrRoundInterrupted)
+}
+
+func TestBroadcasterAggregate(t *testing.T) {
+	const nodeCount = 4
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount)
+	require.NoError(t, err)
+
+	signers := make([]*bls.Signer, nodeCount)
+	incls := make([]*dagquorum.Includer, nodeCount)
+	for i := range signers {
+		pubK, privK, err := bls.GenKeys()
+		require.NoError(t, err)
+		signers[i], err = bls.NewSigner(privK)
+		require.NoError(t, err)
+		incls[i] = dagquorum.NewIncluder(pubK, 1)
+	}
+	includers := dagquorum.NewIncludersSet(incls)
+
+	bros := make([]*Broadcaster, nodeCount)
+	for i, h := range net.Hosts() {
+		psub := newPubSub(ctx, t, h)
+		bros[i] = NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID, psub)
+	}
+
+	connect(ctx, t, net)
+	start(t, bros)
+
+	domain := SignatureDomain(testNetworkID, LatestVersion)
+	wg, wgCtx := errgroup.WithContext(ctx)
+	for _, bro := range bros {
+		wg.Go(func() error {
+			msg, err := testMessage(1, bro.signer.ID(), randData(1024))
+			if err != nil {
+				return err
+			}
+
+			qrm := dagquorum.NewAggregateQuorum(includers)
+			err = bro.Broadcast(wgCtx, msg, qrm)
+			if err != nil {
+				return err
+			}
+
+			for _, cert := range qrm.List() {
+				id := cert.Message().ID
+				aggregate, ok := qrm.Aggregate(id)
+				if !assert.True(t, ok, "certificate is not aggregated") {
+					continue
+				}
+				assert.NoError(t, aggregate.Verify(includers, domain, id))
+
+				// the aggregate is bound to its signers
+				forged := &dagquorum.AggregateSignature{
+					Signature: aggregate.Signature,
+					Signers:   slices.Clone(aggregate.Signers),
+				}
+				forged.Signers[0] ^= 1
+				assert.Error(t, forged.Verify(includers, domain, id))
+			}
+			return nil
+		})
+	}
+	require.NoError(t, wg.Wait())
+}
+
+func TestBroadcasterFutureRounds(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	signer := newLocalSigner(t)
+	bro := NewBroadcaster(testNetworkID, signer, &testCertifier{}, &testHasher{}, unmarshalmessageID, nil,
+		WithRoundLimits(4, 16))
+	t.Cleanup(func() { require.NoError(t, bro.queue.stop(ctx)) })
+
+	deliver := func(round uint64) pubsub.ValidationResult {
+		msg, err := testMessage(round, signer.ID(), randData(32))
+		require.NoError(t, err)
+		canonicalID, err := msg.ID.MarshalBinary()
+		require.NoError(t, err)
+		sig, err := signer.Sign(SignatureDomain(testNetworkID, LatestVersion).Tag(canonicalID))
+		require.NoError(t, err)
+
+		msgMsg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		require.NoError(t, err)
+		gsp, err := gossipmsg.NewRootGossip(seg)
+		require.NoError(t, err)
+		require.NoError(t, gsp.SetId(canonicalID))
+		gsp.SetVersion(uint16(LatestVersion))
+		gsp.SetSignature()
+		require.NoError(t, gsp.Signature().SetSigner(sig.Signer))
+		require.NoError(t, gsp.Signature().SetSignature(sig.Body))
+		data, err := msgMsg.Marshal()
+		require.NoError(t, err)
+
+		return bro.deliverGossip(ctx, LatestVersion, "peer", &pubsub.Message{Message: &pb.Message{Data: data}})
+	}
+
+	// gossips of rounds within the window are accepted and relayed
+	assert.Equal(t, pubsub.ValidationAccept, deliver(4))
+	// while far ahead ones are ignored on validation already, without penalizing the relayer
+	assert.Equal(t, pubsub.ValidationIgnore, deliver(5))
+	assert.Equal(t, pubsub.ValidationIgnore, deliver(1<<63))
+}
+
+func TestBroadcasterInterrupt(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(1)
+	require.NoError(t, err)
+
+	// the only node cannot finalize on its own
+	signers, includers := newIncluders(t, 4, 1)
+	bro := NewBroadcaster(testNetworkID, signers[0], &testCertifier{}, &testHasher{}, unmarshalmessageID,
+		newPubSub(ctx, t, net.Hosts()[0]))
+	require.NoError(t, bro.Start())
+	t.Cleanup(func() {
+		require.NoError(t, bro.Stop(ctx))
+	})
+
+	msg, err := testMessage(1, bro.signer.ID(), randData(1024))
+	require.NoError(t, err)
+	qrm := dagquorum.NewQuorum(includers)
+
+	broadcast := func() error {
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond*100)
+		defer cancel()
+		return bro.Broadcast(ctx, msg, qrm)
+	}
+
+	// the interrupted round keeps the partial state
+	err = broadcast()
+	require.ErrorIs(t, err, rebro.ErrRoundInterrupted)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	cert, ok := qrm.Get(msg.ID)
+	require.True(t, ok)
+	assert.Len(t, cert.Signatures(), 1)
+
+	// and can be resumed
+	err = broadcast()
+	require.ErrorIs(t, err, rebro.ErrRoundInterrupted)
+
+	// until abandoned
+	err = bro.AbandonRound(1)
+	require.NoError(t, err)
+	err = broadcast()
+	require.ErrorIs(t, err, round.ErrElapsedRound)
+}
+
+func TestBroadcasterResume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+
+	// both nodes are required to finalize
+	signers, includers := newIncluders(t, 2, 1)
+	bros := make([]*Broadcaster, 2)
+	for i, h := range net.Hosts() {
+		bros[i] = NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID,
+			newPubSub(ctx, t, h))
+	}
+	connect(ctx, t, net)
+	start(t, bros)
+	t.Cleanup(func() {
+		for _, bro := range bros {
+			require.NoError(t, bro.Stop(ctx))
+		}
+	})
+
+	msg, err := testMessage(1, bros[0].signer.ID(), randData(1024))
+	require.NoError(t, err)
+	qrm := dagquorum.NewQuorum(includers)
+	interruptCtx, interruptCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer interruptCancel()
+	err = bros[0].Broadcast(interruptCtx, msg, qrm)
+	require.ErrorIs(t, err, rebro.ErrRoundInterrupted)
+
+	// gossips of the other node arrive while the round is interrupted
+	otherMsg, err := testMessage(1, bros[1].signer.ID(), randData(1024))
+	require.NoError(t, err)
+	h, err := bros[1].BroadcastAsync(ctx, otherMsg, dagquorum.NewQuorum(includers))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		bros[0].parked.mu.Lock()
+		defer bros[0].parked.mu.Unlock()
+		// the data and signatures over both messages
+		return bros[0].parked.count >= 3
+	}, time.Second*5, time.Millisecond*10)
+
+	// and are not lost once it resumes
+	err = bros[0].Broadcast(ctx, msg, qrm)
+	require.NoError(t, err)
+	require.NoError(t, h.AwaitFinalized(ctx))
+	assert.Len(t, qrm.List(), 2)
+}
+
+func TestBroadcasterProgress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(1)
+	require.NoError(t, err)
+
+	// the only node cannot finalize on its own, so the round hangs
+	signers, includers := newIncluders(t, 4, 1)
+	bro := NewBroadcaster(testNetworkID, signers[0], &testCertifier{}, &testHasher{}, unmarshalmessageID,
+		newPubSub(ctx, t, net.Hosts()[0]))
+	require.NoError(t, bro.Start())
+	t.Cleanup(func() {
+		require.NoError(t, bro.Stop(ctx))
+	})
+
+	msg, err := testMessage(1, bro.signer.ID(), randData(1024))
+	require.NoError(t, err)
+	broadcastCtx, broadcastCancel := context.WithCancel(ctx)
+	h, err := bro.BroadcastAsync(broadcastCtx, msg, dagquorum.NewQuorum(includers))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		// interrupt the hanging round
+		broadcastCancel()
+		require.ErrorIs(t, h.AwaitFinalized(ctx), rebro.ErrRoundInterrupted)
+	})
+	for ev := range h.Events() {
+		if ev.Kind == rebro.EventSignatureAdded {
+			break
+		}
+	}
+
+	progress, err := bro.Progress(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, progress.Finalized)
+	assert.Len(t, progress.Absent, 3)
+	require.Len(t, progress.Certificates, 1)
+	assert.Equal(t, msg.ID.String(), progress.Certificates[0].ID.String())
+	assert.Equal(t, [][]byte{bro.signer.ID()}, progress.Certificates[0].Signed)
+	assert.Len(t, progress.Certificates[0].Missing, 3)
+	assert.EqualValues(t, 1, progress.Certificates[0].Weight)
+	assert.EqualValues(t, 3, progress.Certificates[0].Required)
+
+	_, err = bro.Progress(ctx, 2)
+	assert.Error(t, err)
+}
+
+func TestBroadcasterLateSignatures(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"grace period", WithRoundGracePeriod(time.Millisecond * 500)},
+		{"next round", WithStopOnNextRound()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testLateSignatures(t, tt.opt)
+		})
+	}
+}
+
+func testLateSignatures(t *testing.T, opt Option) {
+	const nodeCount = 10
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount)
+	require.NoError(t, err)
+
+	signers, includers := newIncluders(t, nodeCount, 1)
+
+	bros := make([]*Broadcaster, nodeCount)
+	for i, h := range net.Hosts() {
+		psub := newPubSub(ctx, t, h)
+		bros[i] = NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID, psub, opt)
+	}
+
+	connect(ctx, t, net)
+	start(t, bros)
+
+	broadcast := func(round uint64) []*dagquorum.Quorum {
+		quorums := make([]*dagquorum.Quorum, nodeCount)
+		wg, wgCtx := errgroup.WithContext(ctx)
+		for i, bro := range bros {
+			quorums[i] = dagquorum.NewQuorum(includers)
+			wg.Go(func() error {
+				msg, err := testMessage(round, bro.signer.ID(), randData(1024))
+				if err != nil {
+					return err
+				}
+				return bro.Broadcast(wgCtx, msg, quorums[i])
+			})
+		}
+		require.NoError(t, wg.Wait())
+		return quorums
+	}
+
+	// finalized rounds keep collecting signatures from everyone
+	quorums := broadcast(1)
+	require.Eventually(t, func() bool {
+		for _, qrm := range quorums {
+			certs := qrm.List()
+			if len(certs) != nodeCount {
+				return false
+			}
+			for _, cert := range certs {
+				if len(cert.Signatures()) != nodeCount {
+					return false
+				}
+			}
+		}
+		return true
+	}, time.Second*5, time.Millisecond*10)
+
+	// but eventually stop
+	broadcast(2)
+	for _, bro := range bros {
+		require.Eventually(t, func() bool {
+			getCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+			defer cancel()
+			_, err := bro.rounds.GetRound(getCtx, 1)
+			return err != nil
+		}, time.Second*5, time.Millisecond*10)
+	}
+}
+
+func broadcasterGood(t *testing.T, host host.Host) *Broadcaster {
+	psub := newPubSub(context.Background(), t, host)
 	bro := NewBroadcaster(testNetworkID, newTestSigner(), &testCertifier{}, &testHasher{}, unmarshalmessageID, psub)
 	return bro
 }
 
+func newPubSub(ctx context.Context, t *testing.T, host host.Host) *pubsub.PubSub {
+	psub, err := pubsub.NewGossipSub(ctx, host, PubSubOptions(testNetworkID, 10, NewScorer())...)
+	require.NoError(t, err)
+	return psub
+}
+
 func connect(ctx context.Context, t *testing.T, net mocknet.Mocknet) {
 	hs := net.Hosts()
 	subs := make([]event.Subscription, len(hs))
@@ -98,6 +596,13 @@ func start(t *testing.T, bros []*Broadcaster) {
 		err := bro.Start()
 		require.NoError(t, err)
 	}
+
+	// ensure broadcasters know about each other before publishing
+	for _, bro := range bros {
+		require.Eventually(t, func() bool {
+			return len(bro.topic.ListPeers()) >= len(bros)-1
+		}, time.Second*5, time.Millisecond*10)
+	}
 }
 
 func message(round int, bro *Broadcaster) rebro.Message {
@@ -213,8 +718,9 @@ func (m *messageID) MarshalBinary() (buf []byte, err error) {
 
 func (m *messageID) UnmarshalBinary(bytes []byte) error {
 	m.round = binary.LittleEndian.Uint64(bytes)
-	m.signer = bytes[8 : 8+32]
-	m.hash = bytes[8+32:]
+	// signers may have keys of any size, while hashes are always sha256
+	m.signer = bytes[8 : len(bytes)-sha256.Size]
+	m.hash = bytes[len(bytes)-sha256.Size:]
 	return nil
 }
 
@@ -264,7 +770,10 @@ func (q *quorum) Delete(id rebro.MessageID) bool {
 func (q *quorum) List() []rebro.Certificate {
 	list := make([]rebro.Certificate, 0, len(q.comms))
 	for _, comm := range q.comms {
-		list = append(list, comm)
+		// certificates may still come after finalization
+		if len(comm.sigs) >= q.Threshold {
+			list = append(list, comm)
+		}
 	}
 
 	return list
@@ -0,0 +1,108 @@
+package gossip
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/iykyk-syn/unison/crypto"
+)
+
+// bundleEntry is a signature over the canonical MessageID waiting to be published.
+type bundleEntry struct {
+	canonicalID []byte
+	signature   crypto.Signature
+}
+
+// bundler batches signatures of a round, so that they are published in a single gossip.
+// A bundle is flushed once it reaches the size limit or after the delay since its first entry.
+type bundler struct {
+	size    int
+	delay   time.Duration
+	timeout time.Duration
+	flush   func(context.Context, []bundleEntry) error
+
+	mu      sync.Mutex
+	bundles map[uint64]*bundle
+
+	log *slog.Logger
+}
+
+// bundle keeps signatures of a round yet to be flushed.
+type bundle struct {
+	entries []bundleEntry
+	timer   *time.Timer
+}
+
+func newBundler(params Parameters, flush func(context.Context, []bundleEntry) error) *bundler {
+	return &bundler{
+		size:    params.SignatureBundleSize,
+		delay:   params.SignatureBundleDelay,
+		timeout: params.ProcessingTimeout,
+		flush:   flush,
+		bundles: make(map[uint64]*bundle),
+		log:     slog.With("module", "bundler"),
+	}
+}
+
+// add adds the signature to the bundle of the round, flushing it right away if it is full.
+func (b *bundler) add(ctx context.Context, round uint64, entry bundleEntry) error {
+	b.mu.Lock()
+	bnd, ok := b.bundles[round]
+	if !ok {
+		bnd = &bundle{}
+		b.bundles[round] = bnd
+		if b.size > 1 {
+			bnd.timer = time.AfterFunc(b.delay, func() {
+				b.expire(round, bnd)
+			})
+		}
+	}
+
+	bnd.entries = append(bnd.entries, entry)
+	if len(bnd.entries) < b.size {
+		b.mu.Unlock()
+		return nil
+	}
+
+	delete(b.bundles, round)
+	if bnd.timer != nil {
+		bnd.timer.Stop()
+	}
+	b.mu.Unlock()
+	return b.flush(ctx, bnd.entries)
+}
+
+// expire flushes the bundle once its delay elapses.
+func (b *bundler) expire(round uint64, bnd *bundle) {
+	b.mu.Lock()
+	// the bundle could have been flushed or dropped already
+	if b.bundles[round] != bnd {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.bundles, round)
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	err := b.flush(ctx, bnd.entries)
+	if err != nil {
+		b.log.ErrorContext(ctx, "flushing signatures", "round", round, "entries", len(bnd.entries), "err", err)
+	}
+}
+
+// stop drops all the pending bundles.
+func (b *bundler) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for round, bnd := range b.bundles {
+		if bnd.timer != nil {
+			bnd.timer.Stop()
+		}
+		delete(b.bundles, round)
+	}
+}
@@ -0,0 +1,147 @@
+package gossip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/rebro/gossip/gossipmsg"
+)
+
+func TestBundler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	flushed := make(chan []bundleEntry, 4)
+	params := DefaultParameters()
+	params.SignatureBundleSize = 3
+	params.SignatureBundleDelay = time.Millisecond * 50
+	b := newBundler(params, func(_ context.Context, entries []bundleEntry) error {
+		flushed <- entries
+		return nil
+	})
+
+	entry := func(id string) bundleEntry {
+		return bundleEntry{canonicalID: []byte(id)}
+	}
+
+	// full bundles are flushed right away, per round
+	for _, id := range []string{"a", "b", "x", "c"} {
+		round := uint64(1)
+		if id == "x" {
+			round = 2
+		}
+		require.NoError(t, b.add(ctx, round, entry(id)))
+	}
+	select {
+	case entries := <-flushed:
+		assert.Equal(t, []bundleEntry{entry("a"), entry("b"), entry("c")}, entries)
+	default:
+		t.Fatal("full bundle is not flushed")
+	}
+
+	// the rest is flushed after the delay
+	select {
+	case entries := <-flushed:
+		assert.Equal(t, []bundleEntry{entry("x")}, entries)
+	case <-ctx.Done():
+		t.Fatal("bundle is not flushed after the delay")
+	}
+
+	// pending bundles are dropped on stop
+	require.NoError(t, b.add(ctx, 3, entry("y")))
+	b.stop()
+	select {
+	case <-flushed:
+		t.Fatal("bundle flushed after stop")
+	case <-time.After(params.SignatureBundleDelay * 2):
+	}
+}
+
+func TestVerifySignatures(t *testing.T) {
+	signer, stranger := newLocalSigner(t), newLocalSigner(t)
+	bro := NewBroadcaster(testNetworkID, signer, &testCertifier{}, &testHasher{}, unmarshalmessageID, nil)
+	domain := SignatureDomain(testNetworkID, LatestVersion)
+
+	bundle := func(rounds ...uint64) gossipmsg.Gossip {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		require.NoError(t, err)
+		gsp, err := gossipmsg.NewRootGossip(seg)
+		require.NoError(t, err)
+		gsp.SetVersion(uint16(LatestVersion))
+
+		list, err := gsp.NewSignatures(int32(len(rounds)))
+		require.NoError(t, err)
+		for i, round := range rounds {
+			msg, err := testMessage(round, signer.ID(), randData(8))
+			require.NoError(t, err)
+			canonicalID, err := msg.ID.MarshalBinary()
+			require.NoError(t, err)
+			signature, err := signer.Sign(domain.Tag(canonicalID))
+			require.NoError(t, err)
+
+			require.NoError(t, list.At(i).SetId(canonicalID))
+			require.NoError(t, list.At(i).SetSigner(signature.Signer))
+			require.NoError(t, list.At(i).SetSignature(signature.Body))
+		}
+		return gsp
+	}
+
+	vgs, err := bro.verifyGossip(bundle(1, 1, 1))
+	require.NoError(t, err)
+	require.Len(t, vgs, 3)
+	for _, vg := range vgs {
+		assert.EqualValues(t, 1, vg.id.Round())
+		assert.NotNil(t, vg.signature)
+	}
+
+	_, err = bro.verifyGossip(bundle(1, 2))
+	assert.Error(t, err)
+	_, err = bro.verifyGossip(bundle())
+	assert.Error(t, err)
+
+	// bundles are bounded
+	oversized := make([]uint64, maxSignatureBundleSize+1)
+	for i := range oversized {
+		oversized[i] = 1
+	}
+	_, err = bro.verifyGossip(bundle(oversized...))
+	assert.Error(t, err)
+
+	// and published by a single signer, even if every signature is valid on its own
+	gsp := bundle(1, 1)
+	entries, err := gsp.Signatures()
+	require.NoError(t, err)
+	msg, err := testMessage(1, stranger.ID(), randData(8))
+	require.NoError(t, err)
+	canonicalID, err := msg.ID.MarshalBinary()
+	require.NoError(t, err)
+	signature, err := stranger.Sign(domain.Tag(canonicalID))
+	require.NoError(t, err)
+	require.NoError(t, entries.At(1).SetId(canonicalID))
+	require.NoError(t, entries.At(1).SetSigner(signature.Signer))
+	require.NoError(t, entries.At(1).SetSignature(signature.Body))
+	_, err = bro.verifyGossip(gsp)
+	assert.Error(t, err)
+
+	// a single forged signature rejects the whole bundle
+	gsp = bundle(1, 1)
+	entries, err = gsp.Signatures()
+	require.NoError(t, err)
+	require.NoError(t, entries.At(1).SetSignature(make([]byte, 64)))
+	_, err = bro.verifyGossip(gsp)
+	assert.Error(t, err)
+
+	// as well as signatures produced within another domain
+	gsp = bundle(1, 1)
+	gsp.SetVersion(uint16(LatestVersion + 1))
+	_, err = bro.verifyGossip(gsp)
+	assert.Error(t, err)
+	bro.networkID = "other"
+	_, err = bro.verifyGossip(bundle(1, 1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,226 @@
+package byzantine
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// Honest proposes a random message every round and attests to every observed message, like
+// an honest node would. It is useful to combine with other faulty behaviors, so that the
+// byzantine node is indistinguishable from the honest one.
+func Honest(size int) Behavior {
+	return &honest{size: size}
+}
+
+type honest struct {
+	size int
+}
+
+func (b *honest) Propose(ctx context.Context, n *Node, round uint64) error {
+	msg, err := n.Message(round, n.Signer().ID(), randData(b.size))
+	if err != nil {
+		return err
+	}
+	return n.PublishData(ctx, msg)
+}
+
+func (b *honest) React(ctx context.Context, n *Node, id rebro.MessageID) error {
+	canonicalID, err := id.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	sig, err := n.Signer().Sign(canonicalID)
+	if err != nil {
+		return err
+	}
+	return n.PublishSignature(ctx, id, sig)
+}
+
+// Equivocate proposes the given number of different messages every round.
+func Equivocate(count, size int) Behavior {
+	return &equivocate{count: count, size: size}
+}
+
+type equivocate struct {
+	nopReact
+	count, size int
+}
+
+func (b *equivocate) Propose(ctx context.Context, n *Node, round uint64) error {
+	var err error
+	for range b.count {
+		msg, msgErr := n.Message(round, n.Signer().ID(), randData(b.size))
+		if msgErr != nil {
+			return msgErr
+		}
+		err = errors.Join(err, n.PublishData(ctx, msg))
+	}
+	return err
+}
+
+// InvalidSignatures attests to every observed message with a random signature.
+func InvalidSignatures() Behavior {
+	return &invalidSignatures{}
+}
+
+type invalidSignatures struct {
+	nopPropose
+}
+
+func (b *invalidSignatures) React(ctx context.Context, n *Node, id rebro.MessageID) error {
+	sig := crypto.Signature{
+		Body:   randData(64),
+		Signer: n.Signer().ID(),
+	}
+	return n.PublishSignature(ctx, id, sig)
+}
+
+// ForgedSignatures attests to every observed message with a valid signature of the node
+// claiming it was produced by the message proposer.
+func ForgedSignatures() Behavior {
+	return &forgedSignatures{}
+}
+
+type forgedSignatures struct {
+	nopPropose
+}
+
+func (b *forgedSignatures) React(ctx context.Context, n *Node, id rebro.MessageID) error {
+	canonicalID, err := id.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	sig, err := n.Signer().Sign(canonicalID)
+	if err != nil {
+		return err
+	}
+	sig.Signer = id.Signer()
+	return n.PublishSignature(ctx, id, sig)
+}
+
+// UnknownSignatures produces the given number of valid signatures every round over messages that
+// were never broadcasted.
+func UnknownSignatures(count int) Behavior {
+	return &unknownSignatures{count: count}
+}
+
+type unknownSignatures struct {
+	nopReact
+	count int
+}
+
+func (b *unknownSignatures) Propose(ctx context.Context, n *Node, round uint64) error {
+	var err error
+	for range b.count {
+		msg, msgErr := n.Message(round, n.Signer().ID(), randData(32))
+		if msgErr != nil {
+			return msgErr
+		}
+
+		canonicalID, msgErr := msg.ID.MarshalBinary()
+		if msgErr != nil {
+			return msgErr
+		}
+
+		sig, msgErr := n.Signer().Sign(canonicalID)
+		if msgErr != nil {
+			return msgErr
+		}
+		err = errors.Join(err, n.PublishSignature(ctx, msg.ID, sig))
+	}
+	return err
+}
+
+// FutureRounds floods the network with the given number of messages for rounds far ahead of the
+// current one.
+func FutureRounds(ahead uint64, count int) Behavior {
+	return &futureRounds{ahead: ahead, count: count}
+}
+
+type futureRounds struct {
+	nopReact
+	ahead uint64
+	count int
+}
+
+func (b *futureRounds) Propose(ctx context.Context, n *Node, round uint64) error {
+	var err error
+	for i := range b.count {
+		msg, msgErr := n.Message(round+b.ahead+uint64(i), n.Signer().ID(), randData(32))
+		if msgErr != nil {
+			return msgErr
+		}
+		err = errors.Join(err, n.PublishData(ctx, msg))
+	}
+	return err
+}
+
+// OversizedData proposes a message of the given size every round.
+func OversizedData(size int) Behavior {
+	return &oversizedData{size: size}
+}
+
+type oversizedData struct {
+	nopReact
+	size int
+}
+
+func (b *oversizedData) Propose(ctx context.Context, n *Node, round uint64) error {
+	msg, err := n.Message(round, n.Signer().ID(), randData(b.size))
+	if err != nil {
+		return err
+	}
+	return n.PublishData(ctx, msg)
+}
+
+// NonIncluder proposes and attests to every observed message on behalf of the given signer, which
+// is expected to be outside the includers set.
+func NonIncluder(signer crypto.Signer, size int) Behavior {
+	return &nonIncluder{signer: signer, size: size}
+}
+
+type nonIncluder struct {
+	signer crypto.Signer
+	size   int
+}
+
+func (b *nonIncluder) Propose(ctx context.Context, n *Node, round uint64) error {
+	msg, err := n.Message(round, b.signer.ID(), randData(b.size))
+	if err != nil {
+		return err
+	}
+	return n.PublishData(ctx, msg)
+}
+
+func (b *nonIncluder) React(ctx context.Context, n *Node, id rebro.MessageID) error {
+	canonicalID, err := id.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	sig, err := crypto.NewDomainSigner(b.signer, n.domain).Sign(canonicalID)
+	if err != nil {
+		return err
+	}
+	return n.PublishSignature(ctx, id, sig)
+}
+
+type nopPropose struct{}
+
+func (nopPropose) Propose(context.Context, *Node, uint64) error { return nil }
+
+type nopReact struct{}
+
+func (nopReact) React(context.Context, *Node, rebro.MessageID) error { return nil }
+
+func randData(size int) []byte {
+	data := make([]byte, size)
+	rand.Read(data) //nolint: errcheck
+	return data
+}
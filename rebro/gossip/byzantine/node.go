@@ -0,0 +1,222 @@
+// Package byzantine provides adversarial node behaviors for testing how the gossiping
+// [rebro.Broadcaster] and its [rebro.QuorumCertificate]s react to malicious quorum participants.
+//
+// A byzantine [Node] joins the same topic as honest nodes, but instead of following the protocol
+// it executes a set of [Behavior]s each round.
+package byzantine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"capnproto.org/go/capnp/v3"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/rebro"
+	"github.com/iykyk-syn/unison/rebro/gossip/gossipmsg"
+)
+
+// MessageFn constructs a valid [rebro.Message] for the given round, proposer and data.
+// It is provided by the test harness, as message format is application-specific.
+type MessageFn func(round uint64, signer []byte, data []byte) (rebro.Message, error)
+
+// Behavior defines a faulty action of a byzantine [Node].
+type Behavior interface {
+	// Propose is called once for every round the [Node] participates in.
+	Propose(context.Context, *Node, uint64) error
+	// React is called for every valid data message of other nodes observed on the network.
+	React(context.Context, *Node, rebro.MessageID) error
+}
+
+// Node is a byzantine participant of the gossiping network executing [Behavior]s.
+type Node struct {
+	topic  *pubsub.Topic
+	domain crypto.Domain
+	sub    *pubsub.Subscription
+
+	signer    crypto.Signer
+	message   MessageFn
+	decoder   rebro.MessageIDDecoder
+	behaviors []Behavior
+
+	cancel context.CancelFunc
+	doneCh chan struct{}
+
+	log *slog.Logger
+}
+
+// NewNode instantiates a new byzantine [Node] over the given joined [pubsub.Topic].
+// Gossips are published with the version of the signature [crypto.Domain] and signed within it.
+func NewNode(
+	topic *pubsub.Topic,
+	domain crypto.Domain,
+	signer crypto.Signer,
+	message MessageFn,
+	decoder rebro.MessageIDDecoder,
+	behaviors ...Behavior,
+) *Node {
+	return &Node{
+		topic:     topic,
+		domain:    domain,
+		signer:    crypto.NewDomainSigner(signer, domain),
+		message:   message,
+		decoder:   decoder,
+		behaviors: behaviors,
+		log:       slog.With("module", "byzantine"),
+	}
+}
+
+// Start subscribes to the topic and starts reacting on messages of other nodes.
+func (n *Node) Start() (err error) {
+	n.sub, err = n.topic.Subscribe()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+	n.doneCh = make(chan struct{})
+	go n.listen(ctx)
+	return nil
+}
+
+// Stop stops the [Node].
+func (n *Node) Stop() {
+	n.cancel()
+	n.sub.Cancel()
+	<-n.doneCh
+}
+
+// Signer returns [crypto.Signer] of the [Node] signing within its [crypto.Domain].
+func (n *Node) Signer() crypto.Signer {
+	return n.signer
+}
+
+// Round executes all the [Behavior]s of the [Node] for the given round.
+func (n *Node) Round(ctx context.Context, round uint64) error {
+	var err error
+	for _, b := range n.behaviors {
+		err = errors.Join(err, b.Propose(ctx, n, round))
+	}
+	return err
+}
+
+// Message constructs a new [rebro.Message] through the [MessageFn].
+func (n *Node) Message(round uint64, signer []byte, data []byte) (rebro.Message, error) {
+	return n.message(round, signer, data)
+}
+
+// PublishData publishes the given [rebro.Message] as a data gossip.
+func (n *Node) PublishData(ctx context.Context, msg rebro.Message) error {
+	canonicalID, err := msg.ID.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	return n.publish(ctx, func(gsp gossipmsg.Gossip) error {
+		if err := gsp.SetId(canonicalID); err != nil {
+			return err
+		}
+		gsp.SetData()
+		return gsp.Data().SetData(msg.Data)
+	})
+}
+
+// PublishSignature publishes the given [crypto.Signature] over the [rebro.MessageID] as a signature
+// gossip.
+func (n *Node) PublishSignature(ctx context.Context, id rebro.MessageID, sig crypto.Signature) error {
+	canonicalID, err := id.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	return n.publish(ctx, func(gsp gossipmsg.Gossip) error {
+		gsp.SetSignature()
+		if err := gsp.SetId(canonicalID); err != nil {
+			return err
+		}
+		if err := gsp.Signature().SetSignature(sig.Body); err != nil {
+			return err
+		}
+		return gsp.Signature().SetSigner(sig.Signer)
+	})
+}
+
+// publish prepares and publishes a gossip to the network.
+func (n *Node) publish(ctx context.Context, setter func(gossipmsg.Gossip) error) error {
+	msgMsg, msgSegment, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return err
+	}
+
+	msg, err := gossipmsg.NewRootGossip(msgSegment)
+	if err != nil {
+		return err
+	}
+
+	if err = setter(msg); err != nil {
+		return err
+	}
+	msg.SetVersion(n.domain.Version)
+
+	bytes, err := msgMsg.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return n.topic.Publish(ctx, bytes)
+}
+
+// listen reads all the gossips delivered by the network and calls [Behavior.React] over data
+// messages of other nodes.
+func (n *Node) listen(ctx context.Context) {
+	defer close(n.doneCh)
+	for {
+		gossip, err := n.sub.Next(ctx)
+		if err != nil {
+			return
+		}
+		if gossip.Local {
+			continue
+		}
+
+		id, err := n.dataID(gossip.Data)
+		if err != nil {
+			continue
+		}
+
+		for _, b := range n.behaviors {
+			if err := b.React(ctx, n, id); err != nil {
+				n.log.DebugContext(ctx, "reacting", "err", err)
+			}
+		}
+	}
+}
+
+// errNotData is returned by dataID for gossips of other types.
+var errNotData = errors.New("not a data gossip")
+
+// dataID extracts [rebro.MessageID] out of data gossip.
+func (n *Node) dataID(data []byte) (rebro.MessageID, error) {
+	msgMsg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	gsp, err := gossipmsg.ReadRootGossip(msgMsg)
+	if err != nil {
+		return nil, err
+	}
+	if gsp.Which() != gossipmsg.Gossip_Which_data {
+		return nil, errNotData
+	}
+
+	canonicalID, err := gsp.Id()
+	if err != nil {
+		return nil, err
+	}
+
+	return n.decoder(canonicalID)
+}
@@ -0,0 +1,331 @@
+package gossip
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/crypto/local"
+	"github.com/iykyk-syn/unison/crypto/secp256k1"
+	dagquorum "github.com/iykyk-syn/unison/dag/quorum"
+	"github.com/iykyk-syn/unison/rebro"
+	"github.com/iykyk-syn/unison/rebro/gossip/byzantine"
+)
+
+func TestBroadcasterByzantine(t *testing.T) {
+	tests := []struct {
+		name      string
+		behaviors func() []byzantine.Behavior
+	}{
+		{
+			name: "silent",
+			behaviors: func() []byzantine.Behavior {
+				return nil
+			},
+		},
+		{
+			name: "equivocation",
+			behaviors: func() []byzantine.Behavior {
+				return []byzantine.Behavior{byzantine.Equivocate(2, 1024)}
+			},
+		},
+		{
+			name: "invalid signatures",
+			behaviors: func() []byzantine.Behavior {
+				return []byzantine.Behavior{byzantine.Honest(1024), byzantine.InvalidSignatures()}
+			},
+		},
+		{
+			name: "forged signatures",
+			behaviors: func() []byzantine.Behavior {
+				return []byzantine.Behavior{byzantine.ForgedSignatures()}
+			},
+		},
+		{
+			name: "unknown signatures",
+			behaviors: func() []byzantine.Behavior {
+				return []byzantine.Behavior{byzantine.UnknownSignatures(10)}
+			},
+		},
+		{
+			name: "future rounds",
+			behaviors: func() []byzantine.Behavior {
+				return []byzantine.Behavior{byzantine.FutureRounds(1<<62, 10)}
+			},
+		},
+		{
+			name: "oversized data",
+			behaviors: func() []byzantine.Behavior {
+				// just over the data published in a single gossip by honest nodes
+				return []byzantine.Behavior{byzantine.OversizedData(DefaultParameters().ChunkSize + 1)}
+			},
+		},
+		{
+			name: "non includer",
+			behaviors: func() []byzantine.Behavior {
+				return []byzantine.Behavior{byzantine.NonIncluder(newLocalSigner(t), 1024)}
+			},
+		},
+		{
+			name: "mixed",
+			behaviors: func() []byzantine.Behavior {
+				return []byzantine.Behavior{
+					byzantine.Equivocate(3, 1024),
+					byzantine.ForgedSignatures(),
+					byzantine.InvalidSignatures(),
+					byzantine.FutureRounds(10, 5),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testByzantine(t, tt.behaviors)
+		})
+	}
+}
+
+// testByzantine runs a network of 3f+1 nodes with f of them being byzantine and asserts
+// honest nodes keep finalizing rounds with valid certificates.
+func testByzantine(t *testing.T, behaviors func() []byzantine.Behavior) {
+	const (
+		faulty     = 3
+		nodeCount  = faulty*3 + 1
+		roundCount = 3
+		stake      = 1000
+	)
+
+	// slow runs, e.g. with the race detector, are bounded by the test deadline instead
+	timeout := time.Second * 10
+	if deadline, ok := t.Deadline(); ok {
+		timeout = max(timeout, time.Until(deadline)-time.Second*10)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount)
+	require.NoError(t, err)
+
+	signers, includers := newIncluders(t, nodeCount, stake)
+
+	bros := make([]*Broadcaster, 0, nodeCount-faulty)
+	nodes := make([]*byzantine.Node, 0, faulty)
+	for i, h := range net.Hosts() {
+		psub := newPubSub(ctx, t, h)
+
+		if i < faulty {
+			topic, err := psub.Join(TopicName(testNetworkID, LatestVersion))
+			require.NoError(t, err)
+
+			node := byzantine.NewNode(topic, SignatureDomain(testNetworkID, LatestVersion), signers[i], testMessage, unmarshalmessageID, behaviors()...)
+			nodes = append(nodes, node)
+			continue
+		}
+
+		bro := NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID, psub)
+		bros = append(bros, bro)
+	}
+
+	connect(ctx, t, net)
+	start(t, bros)
+	for _, node := range nodes {
+		require.NoError(t, node.Start())
+		t.Cleanup(node.Stop)
+	}
+
+	for round := uint64(1); round <= roundCount; round++ {
+		quorums := make([]*dagquorum.Quorum, len(bros))
+		wg, wgCtx := errgroup.WithContext(ctx)
+		for i, bro := range bros {
+			quorums[i] = dagquorum.NewQuorum(includers)
+			wg.Go(func() error {
+				msg, err := testMessage(round, bro.signer.ID(), randData(1024))
+				if err != nil {
+					return err
+				}
+				return bro.Broadcast(wgCtx, msg, &singleProposalQuorum{Quorum: quorums[i]})
+			})
+		}
+		for _, node := range nodes {
+			wg.Go(func() error {
+				// faults of byzantine nodes must not affect honest ones
+				_ = node.Round(wgCtx, round)
+				return nil
+			})
+		}
+
+		err = wg.Wait()
+		require.NoError(t, err, "honest nodes must finalize the round(%d)", round)
+
+		// ensure no two honest nodes have certified different messages of the same proposer
+		proposed := make(map[string]string)
+		for _, qrm := range quorums {
+			assertSafe(t, round, includers, qrm)
+
+			for _, cert := range qrm.List() {
+				id := cert.Message().ID
+				hash, ok := proposed[string(id.Signer())]
+				if ok {
+					assert.Equal(t, hash, id.String(), "conflicting certificates for the same proposer")
+				}
+				proposed[string(id.Signer())] = id.String()
+			}
+		}
+	}
+}
+
+// assertSafe checks that every certificate in the [dagquorum.Quorum] was properly certified.
+func assertSafe(t *testing.T, round uint64, includers *dagquorum.Includers, qrm *dagquorum.Quorum) {
+	required := includers.TotalStake()*2/3 + 1
+	proposers := make(map[string]struct{})
+
+	var finalStake int64
+	for _, cert := range qrm.List() {
+		id := cert.Message().ID
+		assert.Equal(t, round, id.Round())
+
+		proposer := includers.GetByPubKey(id.Signer())
+		require.NotNil(t, proposer, "certificate from non includer")
+		finalStake += proposer.Stake
+
+		_, ok := proposers[string(id.Signer())]
+		assert.False(t, ok, "more than one certificate from the same proposer")
+		proposers[string(id.Signer())] = struct{}{}
+
+		canonicalID, err := id.MarshalBinary()
+		require.NoError(t, err)
+		payload := SignatureDomain(testNetworkID, LatestVersion).Tag(canonicalID)
+
+		var certStake int64
+		signers := make(map[string]struct{})
+		for _, sig := range cert.Signatures() {
+			signer := includers.GetByPubKey(sig.Signer)
+			require.NotNil(t, signer, "signature from non includer")
+			certStake += signer.Stake
+
+			_, ok := signers[string(sig.Signer)]
+			assert.False(t, ok, "duplicate signature")
+			signers[string(sig.Signer)] = struct{}{}
+
+			pubK := ed25519.PublicKey(sig.Signer)
+			assert.True(t, pubK.VerifySignature(payload, sig.Body), "invalid signature")
+		}
+		assert.GreaterOrEqual(t, certStake, required)
+	}
+	assert.GreaterOrEqual(t, finalStake, required)
+}
+
+// singleProposalQuorum is a [dagquorum.Quorum] accepting a single message per signer,
+// which is what makes equivocation safe for the application.
+type singleProposalQuorum struct {
+	*dagquorum.Quorum
+
+	mu       sync.Mutex
+	proposed map[string]string
+}
+
+func (q *singleProposalQuorum) Add(msg rebro.Message) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.proposed == nil {
+		q.proposed = make(map[string]string)
+	}
+	id, ok := q.proposed[string(msg.ID.Signer())]
+	if ok && id != msg.ID.String() {
+		return errors.New("signer has already proposed in the round")
+	}
+	if err := q.Quorum.Add(msg); err != nil {
+		return err
+	}
+	q.proposed[string(msg.ID.Signer())] = msg.ID.String()
+	return nil
+}
+
+func (q *singleProposalQuorum) Delete(id rebro.MessageID) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.Quorum.Delete(id) {
+		return false
+	}
+	delete(q.proposed, string(id.Signer()))
+	return true
+}
+
+func testMessage(round uint64, signer []byte, data []byte) (rebro.Message, error) {
+	hash := sha256.Sum256(data)
+	msg := rebro.Message{
+		ID: &messageID{
+			round:  round,
+			signer: signer,
+			hash:   hash[:],
+		},
+		Data: data,
+	}
+	return msg, msg.Validate()
+}
+
+// newIncluders generates signers together with the includers set of them with equal stakes.
+func newIncluders(t *testing.T, count int, stake int64) ([]*local.Signer, *dagquorum.Includers) {
+	signers := make([]*local.Signer, count)
+	incls := make([]*dagquorum.Includer, count)
+	for i := range signers {
+		signers[i] = newLocalSigner(t)
+		pubK, err := ed25519.BytesToPubKey(signers[i].ID())
+		require.NoError(t, err)
+		incls[i] = dagquorum.NewIncluder(pubK, stake)
+	}
+	return signers, dagquorum.NewIncludersSet(incls)
+}
+
+// newDescribedIncluders generates signers with self-describing keys alternating between ed25519
+// and secp256k1 together with the includers set of their raw keys with equal stakes.
+func newDescribedIncluders(t *testing.T, count int, stake int64) ([]*crypto.DescribedSigner, *dagquorum.Includers) {
+	signers := make([]*crypto.DescribedSigner, count)
+	incls := make([]*dagquorum.Includer, count)
+	for i := range signers {
+		var privK crypto.PrivKey
+		var err error
+		if i%2 == 0 {
+			_, privK, err = ed25519.GenKeys()
+		} else {
+			_, privK, err = secp256k1.GenKeys()
+		}
+		require.NoError(t, err)
+
+		signer, err := local.NewSigner(privK)
+		require.NoError(t, err)
+		signers[i], err = crypto.NewDescribedSigner(signer, privK.Type())
+		require.NoError(t, err)
+		incls[i] = dagquorum.NewIncluder(privK.PubKey(), stake)
+	}
+	return signers, dagquorum.NewIncludersSet(incls)
+}
+
+func newLocalSigner(t *testing.T) *local.Signer {
+	_, privK, err := ed25519.GenKeys()
+	require.NoError(t, err)
+
+	signer, err := local.NewSigner(privK)
+	require.NoError(t, err)
+	return signer
+}
+
+func randData(size int) []byte {
+	data := make([]byte, size)
+	_, _ = rand.Read(data)
+	return data
+}
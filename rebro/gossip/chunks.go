@@ -0,0 +1,218 @@
+package gossip
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// maxAssembliesPerSigner bounds the number of messages of a single signer reassembled per round.
+// Honest signers propose a single message per round.
+const maxAssembliesPerSigner = 2
+
+// errTooManyAssemblies signals that the signer has too many messages reassembled in the round.
+var errTooManyAssemblies = errors.New("too many chunked messages of the signer")
+
+// gossipChunk is a part of the chunked message data.
+// Every chunk is committed to by the Merkle root signed by the signer of the message, so that
+// chunks are authenticated one by one without awaiting the whole data.
+type gossipChunk struct {
+	index, total uint32
+	data         []byte
+	// root of the Merkle tree over all the chunks
+	root []byte
+	// proof of the chunk inclusion under the root
+	proof [][]byte
+	// signature over the commitment to the root
+	signature []byte
+}
+
+// splitData splits the data into chunks of at most the given size.
+func splitData(data []byte, size int) [][]byte {
+	parts := make([][]byte, 0, (len(data)+size-1)/size)
+	for len(data) > size {
+		parts = append(parts, data[:size])
+		data = data[size:]
+	}
+	return append(parts, data)
+}
+
+// chunksCommitment returns the data signed to commit to the chunks of the message.
+func chunksCommitment(canonicalID []byte, total uint32, root []byte) []byte {
+	commitment := make([]byte, 0, len(canonicalID)+4+len(root))
+	commitment = append(commitment, canonicalID...)
+	commitment = binary.BigEndian.AppendUint32(commitment, total)
+	return append(commitment, root...)
+}
+
+// leafHash and nodeHash are domain separated, so that inner nodes cannot be passed off as chunks.
+func leafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// merkleTree builds the Merkle tree over the chunks and returns its root with inclusion proofs
+// of every chunk. The last node of a level with no sibling is promoted to the next level as is.
+func merkleTree(parts [][]byte) ([]byte, [][][]byte) {
+	level := make([][]byte, len(parts))
+	for i, part := range parts {
+		level[i] = leafHash(part)
+	}
+
+	proofs := make([][][]byte, len(parts))
+	for width := 1; len(level) > 1; width *= 2 {
+		for i := range parts {
+			if sibling := (i / width) ^ 1; sibling < len(level) {
+				proofs[i] = append(proofs[i], level[sibling])
+			}
+		}
+
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, nodeHash(level[i], level[i+1]))
+			} else {
+				next = append(next, level[i])
+			}
+		}
+		level = next
+	}
+	return level[0], proofs
+}
+
+// verifyChunkProof verifies the inclusion proof of the chunk at the index out of total under the root.
+func verifyChunkProof(root []byte, index, total uint32, data []byte, proof [][]byte) bool {
+	hash := leafHash(data)
+	for count := total; count > 1; count = (count + 1) / 2 {
+		if sibling := index ^ 1; sibling < count {
+			if len(proof) == 0 {
+				return false
+			}
+			if index%2 == 0 {
+				hash = nodeHash(hash, proof[0])
+			} else {
+				hash = nodeHash(proof[0], hash)
+			}
+			proof = proof[1:]
+		}
+		index /= 2
+	}
+	return len(proof) == 0 && bytes.Equal(hash, root)
+}
+
+// assembler reassembles chunked message data grouped by rounds.
+// It only ever receives chunks verified against the signed commitment of the message signer.
+type assembler struct {
+	mu     sync.Mutex
+	rounds map[uint64]*roundAssemblies
+}
+
+// roundAssemblies keeps assemblies of a single round.
+type roundAssemblies struct {
+	messages map[string]*assembly
+	// counts assemblies of every signer
+	perSigner map[string]int
+}
+
+// assembly keeps chunks of a single message received so far.
+type assembly struct {
+	root     []byte
+	total    uint32
+	parts    [][]byte
+	received int
+	// done is set once the data is reassembled, so that late duplicates are ignored
+	done bool
+}
+
+func newAssembler() *assembler {
+	return &assembler{rounds: make(map[uint64]*roundAssemblies)}
+}
+
+// committed reports whether the message is already being reassembled under the same commitment,
+// so that its signature does not have to be verified again.
+func (a *assembler) committed(id rebro.MessageID, total uint32, root []byte) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ras, ok := a.rounds[id.Round()]
+	if !ok {
+		return false
+	}
+	asm, ok := ras.messages[id.String()]
+	return ok && asm.total == total && bytes.Equal(asm.root, root)
+}
+
+// add adds the verified chunk of the message and returns its data once all the chunks are received.
+// The first chunk fixes the commitment of the message, and chunks under other commitments are rejected.
+// Data is only returned once per message.
+func (a *assembler) add(id rebro.MessageID, chunk *gossipChunk) ([]byte, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ras, ok := a.rounds[id.Round()]
+	if !ok {
+		ras = &roundAssemblies{
+			messages:  make(map[string]*assembly),
+			perSigner: make(map[string]int),
+		}
+		a.rounds[id.Round()] = ras
+	}
+
+	asm, ok := ras.messages[id.String()]
+	if !ok {
+		signer := string(id.Signer())
+		if ras.perSigner[signer] >= maxAssembliesPerSigner {
+			return nil, false, errTooManyAssemblies
+		}
+
+		asm = &assembly{
+			root:  chunk.root,
+			total: chunk.total,
+			parts: make([][]byte, chunk.total),
+		}
+		ras.messages[id.String()] = asm
+		ras.perSigner[signer]++
+	}
+	if asm.done {
+		return nil, false, nil
+	}
+	if asm.total != chunk.total || !bytes.Equal(asm.root, chunk.root) {
+		return nil, false, fmt.Errorf("chunk commitment inconsistent with the first one")
+	}
+	if asm.parts[chunk.index] != nil {
+		return nil, false, nil
+	}
+
+	asm.parts[chunk.index] = chunk.data
+	asm.received++
+	if asm.received < len(asm.parts) {
+		return nil, false, nil
+	}
+
+	asm.done = true
+	data := bytes.Join(asm.parts, nil)
+	asm.parts = nil
+	return data, true, nil
+}
+
+// drop forgets all the chunks of the round.
+func (a *assembler) drop(round uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.rounds, round)
+}
@@ -0,0 +1,160 @@
+package gossip
+
+import (
+	"bytes"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/rebro/gossip/gossipmsg"
+)
+
+func TestMerkleTree(t *testing.T) {
+	for total := 1; total <= 9; total++ {
+		parts := splitData(randData(total*10), 10)
+		require.Len(t, parts, total)
+
+		root, proofs := merkleTree(parts)
+		for i, part := range parts {
+			index := uint32(i)
+			assert.True(t, verifyChunkProof(root, index, uint32(total), part, proofs[i]))
+
+			// chunks are bound to their data and position, while the total is bound by the commitment
+			assert.False(t, verifyChunkProof(root, index, uint32(total), randData(10), proofs[i]))
+			if total > 1 {
+				other := (i + 1) % total
+				assert.False(t, verifyChunkProof(root, uint32(other), uint32(total), part, proofs[i]))
+				assert.False(t, verifyChunkProof(root, index, uint32(total), part, proofs[other]))
+			}
+		}
+	}
+}
+
+func TestAssembler(t *testing.T) {
+	data := randData(1000)
+	parts := splitData(data, 300)
+	require.Len(t, parts, 4)
+	assert.Len(t, parts[3], 100)
+	assert.Equal(t, data, bytes.Join(parts, nil))
+	root, _ := merkleTree(parts)
+
+	msg, err := testMessage(1, []byte("signer"), data)
+	require.NoError(t, err)
+
+	asm := newAssembler()
+	add := func(index int) ([]byte, bool, error) {
+		return asm.add(msg.ID, &gossipChunk{
+			index: uint32(index),
+			total: uint32(len(parts)),
+			data:  parts[index],
+			root:  root,
+		})
+	}
+
+	// chunks are collected in any order, ignoring duplicates
+	for _, index := range []int{3, 1, 1, 0} {
+		_, ok, err := add(index)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+	assert.True(t, asm.committed(msg.ID, uint32(len(parts)), root))
+
+	// the first chunk fixes the commitment
+	_, _, err = asm.add(msg.ID, &gossipChunk{index: 2, total: 5, data: parts[2], root: root})
+	assert.Error(t, err)
+	_, _, err = asm.add(msg.ID, &gossipChunk{index: 2, total: 4, data: parts[2], root: randData(32)})
+	assert.Error(t, err)
+
+	reassembled, ok, err := add(2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, data, reassembled)
+
+	// reassembled only once
+	_, ok, err = add(2)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// the signer cannot hold more assemblies than allowed
+	for i := range maxAssembliesPerSigner {
+		other, err := testMessage(1, []byte("signer"), randData(100))
+		require.NoError(t, err)
+
+		_, _, err = asm.add(other.ID, &gossipChunk{index: 0, total: 2, data: randData(10), root: root})
+		if i < maxAssembliesPerSigner-1 {
+			require.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, errTooManyAssemblies)
+		}
+	}
+
+	asm.drop(1)
+	assert.Empty(t, asm.rounds)
+	assert.False(t, asm.committed(msg.ID, uint32(len(parts)), root))
+}
+
+func TestVerifyChunk(t *testing.T) {
+	signer, stranger := newLocalSigner(t), newLocalSigner(t)
+	bro := NewBroadcaster(testNetworkID, signer, &testCertifier{}, &testHasher{}, unmarshalmessageID, nil)
+
+	msg, err := testMessage(1, signer.ID(), randData(1000))
+	require.NoError(t, err)
+	canonicalID, err := msg.ID.MarshalBinary()
+	require.NoError(t, err)
+
+	parts := splitData(msg.Data, 300)
+	total := uint32(len(parts))
+	root, proofs := merkleTree(parts)
+	domain := chunksDomain(testNetworkID, LatestVersion)
+	signature, err := signer.Sign(domain.Tag(chunksCommitment(canonicalID, total, root)))
+	require.NoError(t, err)
+
+	chunk := func(index, total uint32, data, root, signature []byte, proof [][]byte) gossipmsg.Gossip {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		require.NoError(t, err)
+		gsp, err := gossipmsg.NewRootGossip(seg)
+		require.NoError(t, err)
+
+		require.NoError(t, gsp.SetId(canonicalID))
+		gsp.SetVersion(uint16(LatestVersion))
+		gsp.SetChunk()
+		gsp.Chunk().SetIndex(index)
+		gsp.Chunk().SetTotal(total)
+		require.NoError(t, gsp.Chunk().SetData(data))
+		require.NoError(t, gsp.Chunk().SetRoot(root))
+		require.NoError(t, gsp.Chunk().SetSignature(signature))
+		list, err := gsp.Chunk().NewProof(int32(len(proof)))
+		require.NoError(t, err)
+		for i, hash := range proof {
+			require.NoError(t, list.Set(i, hash))
+		}
+		return gsp
+	}
+
+	for i, part := range parts {
+		vgs, err := bro.verifyGossip(chunk(uint32(i), total, part, root, signature.Body, proofs[i]))
+		require.NoError(t, err)
+		require.Len(t, vgs, 1)
+		assert.Equal(t, part, vgs[0].chunk.data)
+	}
+
+	// forged data
+	_, err = bro.verifyGossip(chunk(0, total, randData(300), root, signature.Body, proofs[0]))
+	assert.Error(t, err)
+	// forged total
+	_, err = bro.verifyGossip(chunk(0, total+1, parts[0], root, signature.Body, proofs[0]))
+	assert.Error(t, err)
+	// proof of another chunk
+	_, err = bro.verifyGossip(chunk(1, total, parts[1], root, signature.Body, proofs[0]))
+	assert.Error(t, err)
+
+	// commitment of a stranger to its own data under the MessageID
+	forged := splitData(randData(1000), 300)
+	forgedRoot, forgedProofs := merkleTree(forged)
+	forgedSignature, err := stranger.Sign(domain.Tag(chunksCommitment(canonicalID, total, forgedRoot)))
+	require.NoError(t, err)
+	_, err = bro.verifyGossip(chunk(0, total, forged[0], forgedRoot, forgedSignature.Body, forgedProofs[0]))
+	assert.Error(t, err)
+}
@@ -13,20 +13,27 @@ import (
 type Gossip capnp.Struct
 type Gossip_signature Gossip
 type Gossip_data Gossip
+type Gossip_chunk Gossip
 type Gossip_Which uint16
 
 const (
-	Gossip_Which_signature Gossip_Which = 0
-	Gossip_Which_data      Gossip_Which = 1
+	Gossip_Which_signature  Gossip_Which = 0
+	Gossip_Which_data       Gossip_Which = 1
+	Gossip_Which_chunk      Gossip_Which = 2
+	Gossip_Which_signatures Gossip_Which = 3
 )
 
 func (w Gossip_Which) String() string {
-	const s = "signaturedata"
+	const s = "signaturedatachunksignatures"
 	switch w {
 	case Gossip_Which_signature:
 		return s[0:9]
 	case Gossip_Which_data:
 		return s[9:13]
+	case Gossip_Which_chunk:
+		return s[13:18]
+	case Gossip_Which_signatures:
+		return s[18:28]
 
 	}
 	return "Gossip_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -36,12 +43,12 @@ func (w Gossip_Which) String() string {
 const Gossip_TypeID = 0xf72bafaeff08c61a
 
 func NewGossip(s *capnp.Segment) (Gossip, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 5})
 	return Gossip(st), err
 }
 
 func NewRootGossip(s *capnp.Segment) (Gossip, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 5})
 	return Gossip(st), err
 }
 
@@ -94,6 +101,14 @@ func (s Gossip) SetId(v []byte) error {
 	return capnp.Struct(s).SetData(0, v)
 }
 
+func (s Gossip) Version() uint16 {
+	return capnp.Struct(s).Uint16(2)
+}
+
+func (s Gossip) SetVersion(v uint16) {
+	capnp.Struct(s).SetUint16(2, v)
+}
+
 func (s Gossip) Signature() Gossip_signature { return Gossip_signature(s) }
 
 func (s Gossip) SetSignature() {
@@ -167,12 +182,139 @@ func (s Gossip_data) SetData(v []byte) error {
 	return capnp.Struct(s).SetData(1, v)
 }
 
+func (s Gossip) Chunk() Gossip_chunk { return Gossip_chunk(s) }
+
+func (s Gossip) SetChunk() {
+	capnp.Struct(s).SetUint16(0, 2)
+}
+
+func (s Gossip_chunk) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Gossip_chunk) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Gossip_chunk) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Gossip_chunk) Index() uint32 {
+	return capnp.Struct(s).Uint32(4)
+}
+
+func (s Gossip_chunk) SetIndex(v uint32) {
+	capnp.Struct(s).SetUint32(4, v)
+}
+
+func (s Gossip_chunk) Total() uint32 {
+	return capnp.Struct(s).Uint32(8)
+}
+
+func (s Gossip_chunk) SetTotal(v uint32) {
+	capnp.Struct(s).SetUint32(8, v)
+}
+
+func (s Gossip_chunk) Data() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s Gossip_chunk) HasData() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s Gossip_chunk) SetData(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+func (s Gossip_chunk) Root() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s Gossip_chunk) HasRoot() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s Gossip_chunk) SetRoot(v []byte) error {
+	return capnp.Struct(s).SetData(2, v)
+}
+
+func (s Gossip_chunk) Proof() (capnp.DataList, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return capnp.DataList(p.List()), err
+}
+
+func (s Gossip_chunk) HasProof() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s Gossip_chunk) SetProof(v capnp.DataList) error {
+	return capnp.Struct(s).SetPtr(3, v.ToPtr())
+}
+
+// NewProof sets the proof field to a newly
+// allocated capnp.DataList, preferring placement in s's segment.
+func (s Gossip_chunk) NewProof(n int32) (capnp.DataList, error) {
+	l, err := capnp.NewDataList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.DataList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, l.ToPtr())
+	return l, err
+}
+func (s Gossip_chunk) Signature() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return []byte(p.Data()), err
+}
+
+func (s Gossip_chunk) HasSignature() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s Gossip_chunk) SetSignature(v []byte) error {
+	return capnp.Struct(s).SetData(4, v)
+}
+
+func (s Gossip) Signatures() (SignatureEntry_List, error) {
+	if capnp.Struct(s).Uint16(0) != 3 {
+		panic("Which() != signatures")
+	}
+	p, err := capnp.Struct(s).Ptr(1)
+	return SignatureEntry_List(p.List()), err
+}
+
+func (s Gossip) HasSignatures() bool {
+	if capnp.Struct(s).Uint16(0) != 3 {
+		return false
+	}
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s Gossip) SetSignatures(v SignatureEntry_List) error {
+	capnp.Struct(s).SetUint16(0, 3)
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewSignatures sets the signatures field to a newly
+// allocated SignatureEntry_List, preferring placement in s's segment.
+func (s Gossip) NewSignatures(n int32) (SignatureEntry_List, error) {
+	capnp.Struct(s).SetUint16(0, 3)
+	l, err := NewSignatureEntry_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return SignatureEntry_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+
 // Gossip_List is a list of Gossip.
 type Gossip_List = capnp.StructList[Gossip]
 
 // NewGossip creates a new list of Gossip.
 func NewGossip_List(s *capnp.Segment, sz int32) (Gossip_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 5}, sz)
 	return capnp.StructList[Gossip](l), err
 }
 
@@ -201,35 +343,173 @@ func (f Gossip_data_Future) Struct() (Gossip_data, error) {
 	p, err := f.Future.Ptr()
 	return Gossip_data(p.Struct()), err
 }
+func (p Gossip_Future) Chunk() Gossip_chunk_Future { return Gossip_chunk_Future{p.Future} }
+
+// Gossip_chunk_Future is a wrapper for a Gossip_chunk promised by a client call.
+type Gossip_chunk_Future struct{ *capnp.Future }
+
+func (f Gossip_chunk_Future) Struct() (Gossip_chunk, error) {
+	p, err := f.Future.Ptr()
+	return Gossip_chunk(p.Struct()), err
+}
+
+type SignatureEntry capnp.Struct
+
+// SignatureEntry_TypeID is the unique identifier for the type SignatureEntry.
+const SignatureEntry_TypeID = 0xefabbff0a60e0f0e
+
+func NewSignatureEntry(s *capnp.Segment) (SignatureEntry, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return SignatureEntry(st), err
+}
+
+func NewRootSignatureEntry(s *capnp.Segment) (SignatureEntry, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return SignatureEntry(st), err
+}
+
+func ReadRootSignatureEntry(msg *capnp.Message) (SignatureEntry, error) {
+	root, err := msg.Root()
+	return SignatureEntry(root.Struct()), err
+}
+
+func (s SignatureEntry) String() string {
+	str, _ := text.Marshal(0xefabbff0a60e0f0e, capnp.Struct(s))
+	return str
+}
+
+func (s SignatureEntry) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (SignatureEntry) DecodeFromPtr(p capnp.Ptr) SignatureEntry {
+	return SignatureEntry(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s SignatureEntry) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s SignatureEntry) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s SignatureEntry) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s SignatureEntry) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s SignatureEntry) Id() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s SignatureEntry) HasId() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s SignatureEntry) SetId(v []byte) error {
+	return capnp.Struct(s).SetData(0, v)
+}
+
+func (s SignatureEntry) Signer() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s SignatureEntry) HasSigner() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s SignatureEntry) SetSigner(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+func (s SignatureEntry) Signature() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s SignatureEntry) HasSignature() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
 
-const schema_fbd8d724be65e33e = "x\xda\x8cQ1K#Q\x18\x9cyo\xef\x92\xe2\x96" +
-	"d\xd9\\\x13\xee\xacb\x91\x98\x18%\x08\x12\xc1\xa4\x09" +
-	"\"Z\xe4Ka\xbf\x98e\xd9\xc2$d#\x82\x95\xad" +
-	"\x9d\x8d`mai*-\x05\x0b\xfd\x03\x16\xda[[" +
-	"[\x88>\xd9\x04\xa2`\x04\xab\xc7\xcc\x1b\xe6\x9ba\xd2" +
-	"\x97uk\xd1\xee*(\xf9\xf7\xeb\xb79z\xf2\xef6" +
-	"\x0e\xb6N \xb3\xa4\xc9\xde&\xcd\xf9p\xee\x19\x7fu" +
-	"\x82@\xe5?\xf7\x09\xbay\xee\x81f\xa5\xf8\xda<s" +
-	"K\xa7\xd3\xa5\x87l\xc5\xd2c\xd6\xf0\xe9SfH\xb3" +
-	"\xfa\xe8_\xe5\xee\x1f^\xd0\xd0\x09\x05T.X\xa0{" +
-	"\xc3\x04\xe0^s\x88\x92\x09\xbaQ\x14\xf6\xca\x815z" +
-	"w\xa2\xa0<f\xe6\xb7\xbd^\xa7W]\x1b\x83(\x0c" +
-	"j\x1do\xb0\xdb\xf7%\xa9\xad43$\xe0\xe4\xab\x80" +
-	"\xe44eA\xd1\xa1\xcaP\x01N\xa9\x05HQS\x96" +
-	"\x15kQ\x18t\xfc>m(\xda\xa0\x89a\xec\x02\xfa" +
-	"\x13\xeeg\x01\xda\xde\x80\x9eX\xe3\xdb\x1ap\xec\x02 " +
-	"IM\xc9(\xa6\xda\xde\xc0\xfbb\xa8\xbf3L\xc5\xa8" +
-	"I\xca\x1fm\x01V\xdc\xa4\x91\x05\xa4\xae)\x9b\x8a6" +
-	"\x8d!?\xd6q\xd6[P\xb6z\x8b\xc9\xc9\x0e\xceR" +
-	"\x01J\x87\xedi\xd5Fq\xde\x03\x00\x00\xff\xff\xf9S" +
-	"\x86\xe6"
+func (s SignatureEntry) SetSignature(v []byte) error {
+	return capnp.Struct(s).SetData(2, v)
+}
+
+// SignatureEntry_List is a list of SignatureEntry.
+type SignatureEntry_List = capnp.StructList[SignatureEntry]
+
+// NewSignatureEntry creates a new list of SignatureEntry.
+func NewSignatureEntry_List(s *capnp.Segment, sz int32) (SignatureEntry_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[SignatureEntry](l), err
+}
+
+// SignatureEntry_Future is a wrapper for a SignatureEntry promised by a client call.
+type SignatureEntry_Future struct{ *capnp.Future }
+
+func (f SignatureEntry_Future) Struct() (SignatureEntry, error) {
+	p, err := f.Future.Ptr()
+	return SignatureEntry(p.Struct()), err
+}
+
+const schema_fbd8d724be65e33e = "x\xda\xa4TMhcU\x14>\xdf\xbd\xef\xcd\x8d:" +
+	"I\xe6\xf0\"\x0c\x05\x09H\x16NZ\x1d'\x99\xd9T" +
+	"\x98\x09b\x90\x01\x03\xefD\x06\xc7\xd9\xc5\xc93\x06\xdb" +
+	"\xbc\xf8\x92\xf8\xb3(\xa2;\x7f*v\xd7\xba2\xa2\xc5" +
+	"\xaaX\x0b.Z\x14])\x14\xdc\x08\x95\xba\x11\x14A" +
+	"p\xa5\x0b\xc1\x85\xd6'7}mB)\x88\x9d\xc5G" +
+	"r\xbf{\xce=\xe7|\xdf\xe3\xdc\xbf\x89\x8as!\x9d" +
+	"\xd7\xa4\xa4\xe0\x9e\x8a_\xbaz\xf7\xccg\xbb\xcd7H" +
+	"\xa6\xa1\xe2\xa9\xafS\xf1\xc7\xeb\xd3\x7f\xd2\x9d\xae\x01Q" +
+	"y\x80\x97\xe1\xbd\x02\x93\xe0\x0a\x91\xf7\x15L\xfc\xc0\xcc" +
+	"\x9e\xbf\xea\xdd\xfb\xce\xf1I\x1b\xf8\x00\xde6L\x82\xe7" +
+	"\x88\xbc\xab\xca\xc4\xdf\\\xbb<\xf3\xcck\x7f\xfcr|" +
+	"\xd2%\xf5*\xbc\x9a2\x16\xe5\x9az\x0cD\xde\x0f\xda" +
+	"\xc4\x99lf\xf5\xf7/?\xfc\x8d\xb8\x80\xf8\xf2\xcf\xc1" +
+	"\x17\x85\xdd\xef\xff\"W\x1b\xa2\xf2\xb6~\x0b\xdeO\xda" +
+	"$X'\xf2\x86\x8e\x19?-\x05\xa8qN\xd55\x0e" +
+	"Qy\xd1\xb9\x01\x1bfQ\x1e:\xa3B\xbf\xba\x86\xa6" +
+	"\xe3(x\"\x0a\xcf\xb7B\xb7\xd7kw\xcf\xb7B\xfb" +
+	"3\xdfk%\xff\xee\xbb\xd9\xe8v\xba\xb3\x0f\xef\x1f\x9a" +
+	"\x8d~\x83|\xc0\x87\x12G;g\x90\x83&\xe2t\x91" +
+	"\xd3FNk\xc8Y\x85\xac\x0d\xf2\xa1\x90&\x0bT\xf0" +
+	"\xbfJ\xf4\xda\xadN#\xdb\x1fDAR'\xb5_\x07" +
+	"D|n\x96\xcf\x19\xb9GC.*0T\x0e\x8a\x88" +
+	"/\xd4\xf9\x92\x91\x8b\x1aRQ\xb8b\xf3\x83h\xa2~" +
+	"<z\xb1?\x88\x08\xc1\xc9\xdb\xba\xf9\xd4\xa0\xf34\x1d" +
+	"\xcc~v\xbf'\x87\x88WJ\xbcbdYC\xdeM" +
+	"zr\x89xX\xe2\xa1\x91\xb75\xe4#\x05V\xc8\xe1" +
+	"\x14\x11\xaf\x15y\xcd\xc8\xfb\x1a\xf2\xa9\x02k\x95\xc3m" +
+	"D\xbcQ\xe4\x0d#\x9fh\xc8\xe7\x0a\xec\xe8\x1cn'" +
+	"\xe2\xad\x12o\x19\xd9\xd4\x90\x1d\x05v\x9d\x1c\xee \xe2" +
+	"o\xeb\xfc\x9d\x91\x1d\x0d\xf9Q!\xdf\xee4\x83\xe7\xed" +
+	"L)\xb2@\xbe\x1f\xf6\x1bs\x13\xc4Q/\xb2Q\x18" +
+	"\xf6'\xce\xf9n\x14\x86OZ\"C\xf05F|\xe6" +
+	"\x965{4\xc9\x0e\xaa\x9d\xbe\x8e^HD;\xad\x1d" +
+	"\"\xc7\xfaX\x9d\xe2\xaa\x91\x874\xc4\xb7\x9a%\xee\xd6" +
+	"f\xb9f\xe4\x11\x0d\xb9n5K\xdc\xbdV\xe7\xc7\x8d" +
+	"\\\xd7\x90\xa6\x82n7'\xda9\x89\xd7\xce\x7fy\xad" +
+	"\xdb\xdd\xb1\xc9I\xbf+S\x13\x1e\xa7U\x1c\x03\xe3e" +
+	"\xc0\xc3:\xbfg\x08i\xfd\x8f\xe5\x0f7\x0b/\x15y" +
+	"\xc9\xf2\xce\x9e\xe5\x0f\xf7\x00/\x94x\xc1\x10\xeer\xff" +
+	"\x8e\x91\x83!\xe2\xf6\x0d\x9e72\xa7!o&z\xa4" +
+	"\x88x\xf1A^4\xf2\xba\x86,\x1f\x9d\xfc\xc8\x9c\x07" +
+	"6\xe7G_\xa9\x0f5\xbe\xd7Ao\xc2\xde3\xe3\xc5" +
+	"BT\x01\xc3\xf8\x0a\xf6\xf2\xc5g\x83\xa8\xd7\x0e;6" +
+	"\xd6\x90\x05*\xf8w\x00r\xf1F\xf6"
 
 func RegisterSchema(reg *schemas.Registry) {
 	reg.Register(&schemas.Schema{
 		String: schema_fbd8d724be65e33e,
 		Nodes: []uint64{
-			0x9856804bd365ed90,
+			0x8e64d7bb2c224981,
 			0xa22d13a650fd2c3b,
+			0xe6f48b712c3e55cc,
+			0xefabbff0a60e0f0e,
 			0xf72bafaeff08c61a,
 		},
 		Compressed: true,
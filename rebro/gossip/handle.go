@@ -0,0 +1,105 @@
+package gossip
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// eventsBufferSize is the number of events kept for a slow consumer before dropping new ones.
+const eventsBufferSize = 256
+
+// handle implements [rebro.BroadcastHandle].
+type handle struct {
+	id string
+
+	eventsMu sync.Mutex
+	events   chan rebro.Event
+	finished bool
+
+	certifiedOnce sync.Once
+	certifiedCh   chan struct{}
+
+	doneCh chan struct{}
+	err    error
+}
+
+func newHandle(id rebro.MessageID) *handle {
+	return &handle{
+		id:          id.String(),
+		events:      make(chan rebro.Event, eventsBufferSize),
+		certifiedCh: make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+func (h *handle) Events() <-chan rebro.Event {
+	return h.events
+}
+
+func (h *handle) AwaitCertified(ctx context.Context) error {
+	select {
+	case <-h.certifiedCh:
+		return nil
+	case <-h.doneCh:
+		select {
+		case <-h.certifiedCh:
+			return nil
+		default:
+		}
+		if h.err != nil {
+			return h.err
+		}
+		return rebro.ErrNotCertified
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *handle) AwaitFinalized(ctx context.Context) error {
+	// prefer the outcome over the context being done at the same time
+	select {
+	case <-h.doneCh:
+		return h.err
+	default:
+	}
+
+	select {
+	case <-h.doneCh:
+		return h.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// observe is the round.Observer streaming the round events.
+func (h *handle) observe(ev rebro.Event) {
+	if ev.Kind == rebro.EventCertified && ev.ID.String() == h.id {
+		h.certifiedOnce.Do(func() {
+			close(h.certifiedCh)
+		})
+	}
+
+	h.eventsMu.Lock()
+	defer h.eventsMu.Unlock()
+	if h.finished {
+		return
+	}
+	select {
+	case h.events <- ev:
+	default:
+		// never block the round on a slow consumer
+	}
+}
+
+// finish terminates the handle with the broadcasting outcome.
+func (h *handle) finish(err error) {
+	h.eventsMu.Lock()
+	h.finished = true
+	close(h.events)
+	h.eventsMu.Unlock()
+
+	h.err = err
+	close(h.doneCh)
+}
@@ -8,8 +8,18 @@ import (
 	"github.com/iykyk-syn/unison/rebro"
 )
 
-// ErrElapsedRound is thrown when a requested height was already provided to [Manager].
-var ErrElapsedRound = errors.New("elapsed round")
+var (
+	// ErrElapsedRound is thrown when a requested height was already provided to [Manager].
+	ErrElapsedRound = errors.New("elapsed round")
+	// ErrFutureRound is thrown when a requested round is too far ahead of the latest one.
+	ErrFutureRound = errors.New("future round")
+	// ErrTooManySubscriptions is thrown when [Manager] cannot keep more subscriptions for rounds to come.
+	ErrTooManySubscriptions = errors.New("too many round subscriptions")
+	// ErrInterruptedRound is thrown when a requested round was interrupted and is yet to be resumed.
+	ErrInterruptedRound = errors.New("interrupted round")
+	// ErrNotInterrupted is thrown when abandoning a round which was not interrupted.
+	ErrNotInterrupted = errors.New("round is not interrupted")
+)
 
 // Manager registers and manages lifecycles for every new [Round].
 // It also provides a simple subscription mechanism in [Manager.GetRound] operations which are fulfilled
@@ -18,32 +28,46 @@ type Manager struct {
 	roundsMu    sync.Mutex
 	rounds      map[uint64]*Round
 	roundSubs   map[uint64]map[chan *Round]struct{}
+	subsCount   int
 	latestRound uint64
+	// rounds stopped before finalization, which can be resumed
+	interrupted map[uint64]struct{}
+
+	roundsAhead uint64
+	maxSubs     int
 }
 
 // NewManager instantiates a new [Manager].
-func NewManager() *Manager {
+// It accepts [Manager.GetRound] requests only for rounds within roundsAhead window from the latest
+// started round and keeps at most maxSubs pending subscriptions.
+func NewManager(roundsAhead uint64, maxSubs int) *Manager {
 	return &Manager{
-		rounds:    make(map[uint64]*Round),
-		roundSubs: make(map[uint64]map[chan *Round]struct{}),
+		rounds:      make(map[uint64]*Round),
+		roundSubs:   make(map[uint64]map[chan *Round]struct{}),
+		interrupted: make(map[uint64]struct{}),
+		roundsAhead: roundsAhead,
+		maxSubs:     maxSubs,
 	}
 }
 
 // Stop performs [Round.Finalize] and [Round.Stop] on all the registered instances of [Round] and
 // then terminates. This ensures we retain in-progress [Round] state.
 func (rm *Manager) Stop(ctx context.Context) error {
-	// lock manager fully and prevent any other actions Round while we stop
 	rm.roundsMu.Lock()
-	defer rm.roundsMu.Unlock()
-
+	rounds := make([]*Round, 0, len(rm.rounds))
 	for _, r := range rm.rounds {
+		rounds = append(rounds, r)
+	}
+	rm.roundsMu.Unlock()
+
+	for _, r := range rounds {
 		err := r.Finalize(ctx)
 		if err != nil {
 			return err
 		}
 
 		err = rm.StopRound(ctx, r.RoundNumber())
-		if err != nil {
+		if err != nil && !errors.Is(err, ErrElapsedRound) {
 			return err
 		}
 	}
@@ -51,23 +75,33 @@ func (rm *Manager) Stop(ctx context.Context) error {
 	return nil
 }
 
-// StartRound instantiates and starts a new [Round].
+// StartRound instantiates and starts a new [Round] with the optional [Observer].
 // It adds the [Round] to the [Manager], notifying all the [Manager.GetRound] waiters.
-func (rm *Manager) StartRound(roundNum uint64, qcomm rebro.QuorumCertificate) (*Round, error) {
+// The [Round] interrupted with [Manager.InterruptRound] can be started again to resume it,
+// unless a newer [Round] has been started in the meantime.
+func (rm *Manager) StartRound(roundNum uint64, qcomm rebro.QuorumCertificate, observer Observer) (*Round, error) {
 	rm.roundsMu.Lock()
 	defer rm.roundsMu.Unlock()
 
-	if rm.latestRound >= roundNum {
+	_, interrupted := rm.interrupted[roundNum]
+	if rm.latestRound >= roundNum && !interrupted {
 		return nil, ErrElapsedRound
 	}
-	rm.latestRound = roundNum
+	// older interrupted rounds cannot be resumed anymore
+	for num := range rm.interrupted {
+		if num <= roundNum {
+			delete(rm.interrupted, num)
+		}
+	}
+	rm.latestRound = max(rm.latestRound, roundNum)
 
-	r := NewRound(roundNum, qcomm)
+	r := NewRound(roundNum, qcomm, observer)
 	subs, ok := rm.roundSubs[roundNum]
 	if ok {
 		for sub := range subs {
 			sub <- r // subs are always buffered, so this won't block
 		}
+		rm.subsCount -= len(subs)
 		delete(rm.roundSubs, roundNum)
 	}
 
@@ -78,6 +112,28 @@ func (rm *Manager) StartRound(roundNum uint64, qcomm rebro.QuorumCertificate) (*
 // StopRound stops [Round] and deletes it from the [Manager] together with the active subscriptions for it.
 // It does not wait for the [Round] finalization and that's a caller's concern.
 func (rm *Manager) StopRound(ctx context.Context, roundNum uint64) error {
+	return rm.stopRound(ctx, roundNum, false)
+}
+
+// InterruptRound stops the unfinalized [Round] the same way [Manager.StopRound] does, but keeps
+// the [Round] resumable with [Manager.StartRound] until it is abandoned with [Manager.AbandonRound].
+func (rm *Manager) InterruptRound(ctx context.Context, roundNum uint64) error {
+	return rm.stopRound(ctx, roundNum, true)
+}
+
+// AbandonRound forgets the interrupted [Round], so it cannot be resumed anymore.
+func (rm *Manager) AbandonRound(roundNum uint64) error {
+	rm.roundsMu.Lock()
+	defer rm.roundsMu.Unlock()
+
+	if _, ok := rm.interrupted[roundNum]; !ok {
+		return ErrNotInterrupted
+	}
+	delete(rm.interrupted, roundNum)
+	return nil
+}
+
+func (rm *Manager) stopRound(ctx context.Context, roundNum uint64, interrupt bool) error {
 	rm.roundsMu.Lock()
 	r, ok := rm.rounds[roundNum]
 	rm.roundsMu.Unlock()
@@ -92,26 +148,62 @@ func (rm *Manager) StopRound(ctx context.Context, roundNum uint64) error {
 
 	rm.roundsMu.Lock()
 	delete(rm.rounds, roundNum)
+	// only the latest round can be resumed
+	if interrupt && roundNum == rm.latestRound {
+		rm.interrupted[roundNum] = struct{}{}
+	}
 	for sub := range rm.roundSubs[roundNum] {
 		close(sub)
 	}
+	rm.subsCount -= len(rm.roundSubs[roundNum])
 	delete(rm.roundSubs, roundNum)
 	rm.roundsMu.Unlock()
 	return nil
 }
 
+// LatestRound reports the number of the latest started [Round].
+func (rm *Manager) LatestRound() uint64 {
+	rm.roundsMu.Lock()
+	defer rm.roundsMu.Unlock()
+	return rm.latestRound
+}
+
+// LookupRound gets the running [Round] by the number without subscribing for it.
+func (rm *Manager) LookupRound(roundNum uint64) (*Round, bool) {
+	rm.roundsMu.Lock()
+	defer rm.roundsMu.Unlock()
+
+	r, ok := rm.rounds[roundNum]
+	return r, ok
+}
+
 // GetRound gets [Round] from local map by the number or subscribes for the [Round] to come, if not found.
+// It rejects rounds too far ahead of the latest one with [ErrFutureRound], interrupted rounds with
+// [ErrInterruptedRound] and subscriptions over the limit with [ErrTooManySubscriptions].
 func (rm *Manager) GetRound(ctx context.Context, roundNum uint64) (*Round, error) {
 	rm.roundsMu.Lock()
+	// older rounds may still be running, until they are stopped
+	r, ok := rm.rounds[roundNum]
+	if ok {
+		rm.roundsMu.Unlock()
+		return r, nil
+	}
 	if rm.latestRound > roundNum {
 		rm.roundsMu.Unlock()
 		return nil, ErrElapsedRound
 	}
+	if _, ok := rm.interrupted[roundNum]; ok {
+		rm.roundsMu.Unlock()
+		return nil, ErrInterruptedRound
+	}
+	if roundNum-rm.latestRound > rm.roundsAhead {
+		rm.roundsMu.Unlock()
+		return nil, ErrFutureRound
+	}
 
-	r, ok := rm.rounds[roundNum]
-	if ok {
+	if rm.subsCount >= rm.maxSubs {
 		rm.roundsMu.Unlock()
-		return r, nil
+		return nil, ErrTooManySubscriptions
 	}
 
 	subs, ok := rm.roundSubs[roundNum]
@@ -122,6 +214,7 @@ func (rm *Manager) GetRound(ctx context.Context, roundNum uint64) (*Round, error
 
 	sub := make(chan *Round, 1)
 	subs[sub] = struct{}{}
+	rm.subsCount++
 	rm.roundsMu.Unlock()
 
 	select {
@@ -133,9 +226,13 @@ func (rm *Manager) GetRound(ctx context.Context, roundNum uint64) (*Round, error
 	case <-ctx.Done():
 		// no need to keep the request, if the caller has canceled
 		rm.roundsMu.Lock()
-		delete(subs, sub)
-		if len(subs) == 0 {
-			delete(rm.roundSubs, roundNum)
+		// the subscription could have been fulfilled or closed concurrently
+		if _, ok := rm.roundSubs[roundNum][sub]; ok {
+			delete(subs, sub)
+			rm.subsCount--
+			if len(subs) == 0 {
+				delete(rm.roundSubs, roundNum)
+			}
 		}
 		rm.roundsMu.Unlock()
 		return nil, ctx.Err()
@@ -1,9 +1,140 @@
 package round
 
-import "testing"
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
 
-func TestManager(t *testing.T) {}
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
-// TODO:
-//  * Subscription test
-//  *
+func TestManagerSubscription(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rm := NewManager(2, 2)
+
+	// subscribe for the round to come
+	roundCh, errCh := make(chan *Round), make(chan error)
+	go func() {
+		r, err := rm.GetRound(ctx, 2)
+		roundCh <- r
+		errCh <- err
+	}()
+	require.Eventually(t, func() bool {
+		rm.roundsMu.Lock()
+		defer rm.roundsMu.Unlock()
+		return rm.subsCount == 1
+	}, time.Second, time.Millisecond)
+
+	r, err := rm.StartRound(2, newQuorum(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, r, <-roundCh)
+	assert.NoError(t, <-errCh)
+	assert.Zero(t, rm.subsCount)
+
+	_, err = rm.GetRound(ctx, 1)
+	assert.ErrorIs(t, err, ErrElapsedRound)
+
+	// older rounds are still provided until stopped
+	_, err = rm.StartRound(3, newQuorum(), nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, rm.LatestRound())
+	older, err := rm.GetRound(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, r, older)
+
+	err = rm.StopRound(ctx, 2)
+	require.NoError(t, err)
+	_, err = rm.GetRound(ctx, 2)
+	assert.ErrorIs(t, err, ErrElapsedRound)
+}
+
+func TestManagerFutureRounds(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rm := NewManager(2, 2)
+
+	// far future rounds are rejected immediately
+	_, err := rm.GetRound(ctx, 3)
+	assert.ErrorIs(t, err, ErrFutureRound)
+	_, err = rm.GetRound(ctx, math.MaxUint64)
+	assert.ErrorIs(t, err, ErrFutureRound)
+
+	// subscriptions are capped
+	subCtx, subCancel := context.WithCancel(ctx)
+	errCh := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := rm.GetRound(subCtx, 2)
+			errCh <- err
+		}()
+	}
+	require.Eventually(t, func() bool {
+		rm.roundsMu.Lock()
+		defer rm.roundsMu.Unlock()
+		return rm.subsCount == 2
+	}, time.Second, time.Millisecond)
+
+	_, err = rm.GetRound(ctx, 1)
+	assert.ErrorIs(t, err, ErrTooManySubscriptions)
+
+	// and canceled subscriptions release the space
+	subCancel()
+	assert.ErrorIs(t, <-errCh, context.Canceled)
+	assert.ErrorIs(t, <-errCh, context.Canceled)
+	assert.Zero(t, rm.subsCount)
+	assert.Empty(t, rm.roundSubs)
+
+	// the window moves together with the latest round
+	_, err = rm.StartRound(2, newQuorum(), nil)
+	require.NoError(t, err)
+	getCtx, getCancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer getCancel()
+	_, err = rm.GetRound(getCtx, 4)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestManagerInterruptRound(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rm := NewManager(2, 2)
+	_, err := rm.StartRound(1, newQuorum(), nil)
+	require.NoError(t, err)
+
+	err = rm.InterruptRound(ctx, 1)
+	require.NoError(t, err)
+	_, err = rm.GetRound(ctx, 1)
+	assert.ErrorIs(t, err, ErrInterruptedRound)
+
+	// interrupted round can be resumed
+	_, err = rm.StartRound(1, newQuorum(), nil)
+	require.NoError(t, err)
+	_, err = rm.GetRound(ctx, 1)
+	require.NoError(t, err)
+	err = rm.InterruptRound(ctx, 1)
+	require.NoError(t, err)
+
+	// unless abandoned
+	err = rm.AbandonRound(1)
+	require.NoError(t, err)
+	err = rm.AbandonRound(1)
+	assert.ErrorIs(t, err, ErrNotInterrupted)
+	_, err = rm.StartRound(1, newQuorum(), nil)
+	assert.ErrorIs(t, err, ErrElapsedRound)
+
+	// or superseded by a newer round
+	_, err = rm.StartRound(2, newQuorum(), nil)
+	require.NoError(t, err)
+	err = rm.InterruptRound(ctx, 2)
+	require.NoError(t, err)
+	_, err = rm.StartRound(3, newQuorum(), nil)
+	require.NoError(t, err)
+	_, err = rm.StartRound(2, newQuorum(), nil)
+	assert.ErrorIs(t, err, ErrElapsedRound)
+	assert.Empty(t, rm.interrupted)
+}
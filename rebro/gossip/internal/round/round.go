@@ -1,6 +1,7 @@
 package round
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -13,10 +14,25 @@ import (
 const (
 	stateOperationsChannelSize      = 32
 	subscriptionCancellationTimeout = time.Second
+	// maxPendingSignaturesPerSigner bounds the number of buffered signatures of a single signer
+	// awaiting their certificates.
+	maxPendingSignaturesPerSigner = 256
+	// maxPendingSignatures bounds the number of buffered signatures of all the signers.
+	maxPendingSignatures = 4096
 )
 
-// ErrClosedRound singles that Round is accessed after being closed
-var ErrClosedRound = errors.New("closed round access")
+var (
+	// ErrClosedRound singles that Round is accessed after being closed
+	ErrClosedRound = errors.New("closed round access")
+	// ErrTooManyPending signals that there are too many signatures awaiting their certificates.
+	ErrTooManyPending = errors.New("too many pending signatures")
+	// ErrNotIncluder signals that the signer does not participate in the round's quorum.
+	ErrNotIncluder = errors.New("signer is not an includer")
+)
+
+// Observer gets notified about progress of the [Round] with [rebro.Event]s.
+// It is called from the state loop, thus must not block.
+type Observer func(rebro.Event)
 
 // Round maintains state of broadcasting rounds and local pubsub system for certificates.
 // It guards [rebro.QuorumCertificate] from concurrent access ensuring thread-safety.
@@ -31,6 +47,16 @@ type Round struct {
 	stateOpCh chan *stateOp
 	// maintains subscriptions for certificates by their ids
 	getOpSubs map[string]map[*stateOp]struct{}
+	// buffers signatures for certificates yet to be added by their ids
+	pendingSigs map[string][]crypto.Signature
+	// counts pending signatures of every signer
+	pendingPerSigner map[string]int
+	// counts pending signatures of all the signers
+	pendingTotal int
+	// keeps ids of completed certificates
+	certified map[string]struct{}
+	// notified about the progress, if set
+	observer Observer
 	// finalCh gets closed when the quorum certificate has been finalized to notify listeners
 	finalCh chan struct{}
 	// signaling for graceful shutdown
@@ -40,15 +66,20 @@ type Round struct {
 // NewRound instantiates a new [Round] state machine wrapping [rebro.QuorumCertificate].
 // This passes full ownership of the [rebro.QuorumCertificate] fully to [Round],
 // thus it must not be used for writes until [Round] has been stopped.
-func NewRound(roundNum uint64, quorum rebro.QuorumCertificate) *Round {
+// The optional [Observer] gets notified about the progress.
+func NewRound(roundNum uint64, quorum rebro.QuorumCertificate, observer Observer) *Round {
 	r := &Round{
-		roundNum:  roundNum,
-		quorum:    quorum,
-		stateOpCh: make(chan *stateOp, stateOperationsChannelSize),
-		getOpSubs: make(map[string]map[*stateOp]struct{}),
-		finalCh:   make(chan struct{}),
-		closeCh:   make(chan struct{}),
-		closedCh:  make(chan struct{}),
+		roundNum:         roundNum,
+		quorum:           quorum,
+		stateOpCh:        make(chan *stateOp, stateOperationsChannelSize),
+		getOpSubs:        make(map[string]map[*stateOp]struct{}),
+		pendingSigs:      make(map[string][]crypto.Signature),
+		pendingPerSigner: make(map[string]int),
+		certified:        make(map[string]struct{}),
+		observer:         observer,
+		finalCh:          make(chan struct{}),
+		closeCh:          make(chan struct{}),
+		closedCh:         make(chan struct{}),
 	}
 	go r.stateLoop()
 	return r
@@ -74,6 +105,13 @@ func (r *Round) Stop(ctx context.Context) error {
 
 // Finalize awaits finalization of the [Round]'s [rebro.QuorumCertificate].
 func (r *Round) Finalize(ctx context.Context) error {
+	// prefer finalization over the context being done at the same time
+	select {
+	case <-r.finalCh:
+		return nil
+	default:
+	}
+
 	select {
 	case <-r.finalCh:
 		return nil
@@ -96,16 +134,20 @@ func (r *Round) AddCertificate(ctx context.Context, msg rebro.Message) error {
 	return r.execOp(ctx, op)
 }
 
-// stateAdd adds certificate to quorum additionally notifying all the subscribers for this certificate.
+// stateAdd adds certificate to quorum additionally notifying all the subscribers for this certificate
+// and applying all the signatures pending for it.
 func (r *Round) stateAdd(op *stateOp) {
+	key := op.msg.ID.String()
 	err := r.quorum.Add(*op.msg)
 	if err != nil {
+		// the certificate won't come anymore
+		r.dropPending(key)
 		op.SetError(err)
 		return
 	}
-	// we added, now lets see if there were any subscribers
-	key := op.msg.ID.String()
-	if len(r.getOpSubs[key]) == 0 {
+	r.notify(rebro.Event{Kind: rebro.EventCertificateAdded, ID: op.msg.ID})
+	// we added, now lets see if there were any subscribers or pending signatures
+	if len(r.getOpSubs[key]) == 0 && len(r.pendingSigs[key]) == 0 {
 		op.SetError(nil)
 		return
 	}
@@ -120,10 +162,23 @@ func (r *Round) stateAdd(op *stateOp) {
 	}
 	// cleaning up the subscriptions
 	delete(r.getOpSubs, key)
+	// apply the early signatures
+	for _, sig := range r.pendingSigs[key] {
+		// signatures were verified before, so invalid ones only affect their signers
+		_ = r.addSignature(comm, sig)
+	}
+	r.dropPending(key)
 	// and finishing the main operation
 	op.SetError(nil)
 }
 
+// IsIncluder reports whether the signer participates in the round's quorum.
+// Quorums not implementing [rebro.IncluderChecker] cannot tell includers apart and accept anyone.
+func (r *Round) IsIncluder(signer []byte) bool {
+	checker, ok := r.quorum.(rebro.IncluderChecker)
+	return !ok || checker.IsIncluder(signer)
+}
+
 // GetCertificate gets certificate from the [Round] by the associated [rebro.MessageID].
 func (r *Round) GetCertificate(ctx context.Context, id rebro.MessageID) (rebro.Certificate, error) {
 	op := newStateOp(getOp)
@@ -182,6 +237,7 @@ func (r *Round) DeleteCertificate(ctx context.Context, id rebro.MessageID) error
 }
 
 func (r *Round) stateDelete(op *stateOp) {
+	r.dropPending(op.id.String())
 	ok := r.quorum.Delete(op.id) // TODO: Maybe error instead?
 	if !ok {
 		op.SetError(fmt.Errorf("coudn't delete Certificate"))
@@ -191,6 +247,7 @@ func (r *Round) stateDelete(op *stateOp) {
 }
 
 // AddSignature appends a Signature to one of the [Round]'s Certificates.
+// If the Certificate is yet to be added, the Signature is buffered and applied once it is.
 func (r *Round) AddSignature(ctx context.Context, id rebro.MessageID, sig crypto.Signature) error {
 	op := newStateOp(addSignOp)
 	op.id = id
@@ -200,43 +257,121 @@ func (r *Round) AddSignature(ctx context.Context, id rebro.MessageID, sig crypto
 }
 
 // stateAddSign adds signature to the quorum and attempts to finalize it. If success, it notifies
-// all the [Round.Finalize] subscribers.
+// all the [Round.Finalize] subscribers. If the certificate is not known yet, it buffers the
+// signature until the certificate is added.
 func (r *Round) stateAddSign(op *stateOp) {
 	comm, ok := r.quorum.Get(op.id)
 	if !ok {
-		op.SetError(fmt.Errorf("coudn't find Certificate"))
+		op.SetError(r.bufferSignature(op.id.String(), *op.sig))
 		return
 	}
 
-	fin, err := comm.AddSignature(*op.sig)
+	op.SetError(r.addSignature(comm, *op.sig))
+}
+
+// addSignature adds signature to the certificate and attempts to finalize the quorum.
+func (r *Round) addSignature(comm rebro.Certificate, sig crypto.Signature) error {
+	fin, err := comm.AddSignature(sig)
 	if err != nil {
-		op.SetError(err)
-		return
+		return err
 	}
+	id := comm.Message().ID
+	r.notify(rebro.Event{Kind: rebro.EventSignatureAdded, ID: id, Signature: sig})
 	// check if the certificate is complete
 	if !fin {
-		op.SetError(nil)
-		return
+		return nil
+	}
+	if _, ok := r.certified[id.String()]; !ok {
+		r.certified[id.String()] = struct{}{}
+		r.notify(rebro.Event{Kind: rebro.EventCertified, ID: id})
 	}
 	// check if the quorum has finalized
-	ok, err = r.quorum.Finalize()
+	ok, err := r.quorum.Finalize()
 	if err != nil {
-		op.SetError(fmt.Errorf("finalizing quorum certificate: %w", err))
-		return
+		return fmt.Errorf("finalizing quorum certificate: %w", err)
 	}
 	if !ok {
-		op.SetError(nil)
-		return
+		return nil
 	}
 	// ok, it's final, notify everyone
 	select {
 	case <-r.finalCh:
 	default:
+		r.notify(rebro.Event{Kind: rebro.EventFinalized})
 		close(r.finalCh)
 	}
+	return nil
+}
+
+// Progress reports the progress of the [Round]'s [rebro.QuorumCertificate] towards finalization.
+// It returns [rebro.ErrProgressUnsupported] if the [rebro.QuorumCertificate] does not implement
+// [rebro.ProgressReporter].
+func (r *Round) Progress(ctx context.Context) (rebro.QuorumProgress, error) {
+	op := newStateOp(progressOp)
+
+	err := r.execOp(ctx, op)
+	if err != nil {
+		return rebro.QuorumProgress{}, err
+	}
+	return op.progress, nil
+}
+
+func (r *Round) stateProgress(op *stateOp) {
+	reporter, ok := r.quorum.(rebro.ProgressReporter)
+	if !ok {
+		op.SetError(rebro.ErrProgressUnsupported)
+		return
+	}
+
+	op.progress = reporter.Progress()
 	op.SetError(nil)
 }
 
+// notify notifies the observer about the event, if any.
+func (r *Round) notify(ev rebro.Event) {
+	if r.observer != nil {
+		r.observer(ev)
+	}
+}
+
+// bufferSignature keeps the signature of an includer until its certificate is added.
+// Includers are only known if the quorum implements [rebro.IncluderChecker], otherwise
+// the buffer is bounded solely by the total cap.
+func (r *Round) bufferSignature(key string, sig crypto.Signature) error {
+	if !r.IsIncluder(sig.Signer) {
+		return ErrNotIncluder
+	}
+
+	signer := string(sig.Signer)
+	if r.pendingPerSigner[signer] >= maxPendingSignaturesPerSigner || r.pendingTotal >= maxPendingSignatures {
+		return ErrTooManyPending
+	}
+
+	for _, pending := range r.pendingSigs[key] {
+		if bytes.Equal(pending.Signer, sig.Signer) {
+			return errors.New("duplicate pending signature from the signer")
+		}
+	}
+
+	r.pendingSigs[key] = append(r.pendingSigs[key], sig)
+	r.pendingPerSigner[signer]++
+	r.pendingTotal++
+	return nil
+}
+
+// dropPending drops all the pending signatures for the certificate.
+func (r *Round) dropPending(key string) {
+	for _, sig := range r.pendingSigs[key] {
+		signer := string(sig.Signer)
+		r.pendingPerSigner[signer]--
+		r.pendingTotal--
+		if r.pendingPerSigner[signer] == 0 {
+			delete(r.pendingPerSigner, signer)
+		}
+	}
+	delete(r.pendingSigs, key)
+}
+
 // execOp submits operation for execution by [stateLoop] and awaits for its completion
 // It permits submission until closedCh is closed or context is canceled, even after closing is
 // triggered. This allows some "last-minute" operations to "squeeze in" before [Round] fully finishes.
@@ -283,6 +418,8 @@ func (r *Round) stateLoop() {
 			r.stateDelete(op)
 		case addSignOp:
 			r.stateAddSign(op)
+		case progressOp:
+			r.stateProgress(op)
 		default:
 			panic("unknown operation type")
 		}
@@ -2,6 +2,7 @@ package round
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"testing"
 	"time"
@@ -18,7 +19,7 @@ func TestRound(t *testing.T) {
 	defer cancel()
 
 	quorum := newQuorum()
-	r := NewRound(0, quorum)
+	r := NewRound(0, quorum, nil)
 	id := &messageID{id: "msgid"}
 
 	// check all the basic crud operations work
@@ -31,7 +32,7 @@ func TestRound(t *testing.T) {
 	require.NoError(t, err)
 	err = r.DeleteCertificate(ctx, id)
 	require.NoError(t, err)
-	err = r.AddSignature(ctx, id, crypto.Signature{})
+	err = r.DeleteCertificate(ctx, id)
 	require.Error(t, err)
 
 	// terminate the round
@@ -41,9 +42,71 @@ func TestRound(t *testing.T) {
 	// ensure we get errors after stopping
 	err = r.AddSignature(ctx, id, crypto.Signature{})
 
-	require.ErrorAs(t, err, ErrClosedRound)
+	require.ErrorIs(t, err, ErrClosedRound)
 	err = r.Stop(ctx)
-	require.ErrorAs(t, err, ErrClosedRound)
+	require.ErrorIs(t, err, ErrClosedRound)
+}
+
+func TestRoundPendingSignatures(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	quorum := newQuorum()
+	r := NewRound(0, quorum, nil)
+	id := &messageID{id: "msgid"}
+
+	// signatures for unknown certificates are buffered
+	err := r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("signer1")})
+	require.NoError(t, err)
+	err = r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("signer2")})
+	require.NoError(t, err)
+	err = r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("signer2")})
+	require.Error(t, err)
+
+	// and applied once the certificate comes
+	err = r.AddCertificate(ctx, rebro.Message{ID: id})
+	require.NoError(t, err)
+	err = r.Finalize(ctx)
+	require.NoError(t, err)
+	assert.Len(t, quorum.comms[id.id].sigs, 2)
+
+	// the buffer is bounded per signer
+	for i := 0; i < maxPendingSignaturesPerSigner; i++ {
+		err = r.AddSignature(ctx, &messageID{id: strconv.Itoa(i)}, crypto.Signature{Signer: []byte("signer1")})
+		require.NoError(t, err)
+	}
+	err = r.AddSignature(ctx, &messageID{id: "overflow"}, crypto.Signature{Signer: []byte("signer1")})
+	require.ErrorIs(t, err, ErrTooManyPending)
+
+	// and freed once the certificate is gone
+	err = r.DeleteCertificate(ctx, &messageID{id: "0"})
+	require.Error(t, err)
+	err = r.AddSignature(ctx, &messageID{id: "overflow"}, crypto.Signature{Signer: []byte("signer1")})
+	require.NoError(t, err)
+
+	err = r.Stop(ctx)
+	require.NoError(t, err)
+	assert.Len(t, r.pendingSigs, maxPendingSignaturesPerSigner)
+
+	// the buffer is bounded in total
+	r = NewRound(0, newQuorum(), nil)
+	for i := range maxPendingSignatures {
+		signer := []byte(strconv.Itoa(i / maxPendingSignaturesPerSigner))
+		err = r.AddSignature(ctx, &messageID{id: strconv.Itoa(i)}, crypto.Signature{Signer: signer})
+		require.NoError(t, err)
+	}
+	err = r.AddSignature(ctx, &messageID{id: "overflow"}, crypto.Signature{Signer: []byte("fresh")})
+	require.ErrorIs(t, err, ErrTooManyPending)
+	require.NoError(t, r.Stop(ctx))
+
+	// and only keeps signatures of includers
+	r = NewRound(0, &includersQuorum{quorum: newQuorum(), includers: []string{"signer1"}}, nil)
+	err = r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("signer1")})
+	require.NoError(t, err)
+	err = r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("stranger")})
+	require.ErrorIs(t, err, ErrNotIncluder)
+	require.NoError(t, r.Stop(ctx))
+	assert.Len(t, r.pendingSigs[id.id], 1)
 }
 
 func TestRoundSubscription(t *testing.T) {
@@ -51,7 +114,7 @@ func TestRoundSubscription(t *testing.T) {
 	defer cancel()
 
 	quorum := newQuorum()
-	r := NewRound(0, quorum)
+	r := NewRound(0, quorum, nil)
 	id := &messageID{id: "msgid"}
 
 	// subscribe for certificate
@@ -93,7 +156,7 @@ func TestRoundGracefulShutdown(t *testing.T) {
 	defer cancel()
 
 	quorum := newQuorum()
-	r := NewRound(0, quorum)
+	r := NewRound(0, quorum, nil)
 
 	for i := 0; i < 10; i++ {
 		err := r.execOpAsync(ctx, &stateOp{
@@ -115,7 +178,7 @@ func TestRoundConcurrentAccess(t *testing.T) {
 	defer cancel()
 
 	quorum := newQuorum()
-	r := NewRound(0, quorum)
+	r := NewRound(0, quorum, nil)
 
 	wg := errgroup.Group{}
 	for i := 0; i < 100; i++ {
@@ -172,7 +235,7 @@ func TestRoundFinalize(t *testing.T) {
 
 	id := &messageID{id: "msgid"}
 	quorum := newQuorum()
-	r := NewRound(0, quorum)
+	r := NewRound(0, quorum, nil)
 
 	go func() {
 		err := r.AddCertificate(ctx, rebro.Message{ID: id})
@@ -209,6 +272,43 @@ func TestRoundFinalize(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestRoundObserver(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events := make(chan rebro.Event, 10)
+	r := NewRound(0, newQuorum(), func(ev rebro.Event) {
+		events <- ev
+	})
+	id := &messageID{id: "msgid"}
+
+	err := r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("early")})
+	require.NoError(t, err)
+	err = r.AddCertificate(ctx, rebro.Message{ID: id})
+	require.NoError(t, err)
+	err = r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("late")})
+	require.NoError(t, err)
+	err = r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("later")})
+	require.NoError(t, err)
+
+	err = r.Stop(ctx)
+	require.NoError(t, err)
+	close(events)
+
+	var kinds []rebro.EventKind
+	for ev := range events {
+		kinds = append(kinds, ev.Kind)
+	}
+	assert.Equal(t, []rebro.EventKind{
+		rebro.EventCertificateAdded,
+		rebro.EventSignatureAdded,
+		rebro.EventSignatureAdded,
+		rebro.EventCertified,
+		rebro.EventFinalized,
+		rebro.EventSignatureAdded,
+	}, kinds)
+}
+
 type messageID struct {
 	round uint64
 	id    string
@@ -250,6 +350,34 @@ type quorum struct {
 	comms map[string]*certificate
 }
 
+func TestRoundProgress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// quorums not reporting progress are not supported
+	r := NewRound(0, newQuorum(), nil)
+	_, err := r.Progress(ctx)
+	require.ErrorIs(t, err, rebro.ErrProgressUnsupported)
+	require.NoError(t, r.Stop(ctx))
+
+	r = NewRound(0, &reportingQuorum{quorum: newQuorum()}, nil)
+	id := &messageID{id: "msgid"}
+	err = r.AddCertificate(ctx, rebro.Message{ID: id})
+	require.NoError(t, err)
+	err = r.AddSignature(ctx, id, crypto.Signature{Signer: []byte("signer")})
+	require.NoError(t, err)
+
+	progress, err := r.Progress(ctx)
+	require.NoError(t, err)
+	require.Len(t, progress.Certificates, 1)
+	assert.Equal(t, id, progress.Certificates[0].ID)
+	assert.Equal(t, [][]byte{[]byte("signer")}, progress.Certificates[0].Signed)
+
+	require.NoError(t, r.Stop(ctx))
+	_, err = r.Progress(ctx)
+	require.ErrorIs(t, err, ErrClosedRound)
+}
+
 func newQuorum() *quorum {
 	return &quorum{
 		comms: map[string]*certificate{},
@@ -310,3 +438,30 @@ func (c *certificate) AddSignature(sig crypto.Signature) (bool, error) {
 func (c *certificate) Quorum() rebro.QuorumCertificate {
 	return c.q
 }
+
+// reportingQuorum is a quorum reporting its progress.
+type reportingQuorum struct {
+	*quorum
+}
+
+func (q *reportingQuorum) Progress() rebro.QuorumProgress {
+	var progress rebro.QuorumProgress
+	for _, comm := range q.comms {
+		cert := rebro.CertificateProgress{ID: comm.msg.ID}
+		for _, sig := range comm.sigs {
+			cert.Signed = append(cert.Signed, sig.Signer)
+		}
+		progress.Certificates = append(progress.Certificates, cert)
+	}
+	return progress
+}
+
+// includersQuorum is a quorum of the given includers.
+type includersQuorum struct {
+	*quorum
+	includers []string
+}
+
+func (q *includersQuorum) IsIncluder(signer []byte) bool {
+	return slices.Contains(q.includers, string(signer))
+}
@@ -13,6 +13,7 @@ const (
 	getOp
 	deleteOp
 	addSignOp
+	progressOp
 )
 
 // stateOp defines operations on the [Round] state machine
@@ -26,8 +27,9 @@ type stateOp struct {
 	sig *crypto.Signature // addSignOp
 
 	// response data:
-	err  error             // addOp, deleteOp, addSignOp
-	comm rebro.Certificate // getOp
+	err      error                // addOp, deleteOp, addSignOp, progressOp
+	comm     rebro.Certificate    // getOp
+	progress rebro.QuorumProgress // progressOp
 }
 
 func newStateOp(kind stateOpKind) *stateOp {
@@ -0,0 +1,34 @@
+package gossip
+
+import "sync/atomic"
+
+// Metrics reports gossip processing statistics of the [Broadcaster].
+type Metrics struct {
+	// Processed is the number of processed gossips.
+	Processed uint64
+	// Throttled is the number of gossips dropped because of exceeded per peer quota.
+	Throttled uint64
+	// Evicted is the number of gossips dropped while waiting for processing to make space for
+	// new ones.
+	Evicted uint64
+	// Expired is the number of gossips dropped because of exceeded processing deadline.
+	Expired uint64
+	// UnknownVersion is the number of gossips rejected because of wire protocol version other
+	// than the one of the topic they were received on.
+	UnknownVersion uint64
+}
+
+// metrics accumulates [Metrics] concurrently.
+type metrics struct {
+	processed, throttled, evicted, expired, unknownVersion atomic.Uint64
+}
+
+func (m *metrics) snapshot() Metrics {
+	return Metrics{
+		Processed:      m.processed.Load(),
+		Throttled:      m.throttled.Load(),
+		Evicted:        m.evicted.Load(),
+		Expired:        m.expired.Load(),
+		UnknownVersion: m.unknownVersion.Load(),
+	}
+}
@@ -0,0 +1,178 @@
+package gossip
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/rebro"
+	"github.com/iykyk-syn/unison/rebro/gossip/internal/round"
+)
+
+// finalizedBufferSize is the number of finalized rounds kept for a slow consumer.
+const finalizedBufferSize = 16
+
+// QuorumFn provides a new [rebro.QuorumCertificate] for the round to be observed.
+// It must error for rounds which should not be observed, e.g. the ones with unknown quorum.
+// The [rebro.QuorumCertificate] must implement [rebro.IncluderChecker], as rounds are only
+// started by signatures of includers.
+type QuorumFn func(round uint64) (rebro.QuorumCertificate, error)
+
+// FinalizedRound is a round finalized by the network as seen by the [Observer].
+type FinalizedRound struct {
+	Round  uint64
+	Quorum rebro.QuorumCertificate
+}
+
+// Observer follows broadcasting rounds of the network without participating in them.
+// It verifies data and signatures, builds certificates and exposes finalized rounds,
+// but never signs or proposes anything.
+//
+// Rounds are started on the first verified signature of an includer for them. The very first
+// round can be any, while the following ones must be within [Parameters.RoundsAhead] window from
+// the latest observed round. Unfinalized rounds preceding a finalized one are stopped.
+type Observer struct {
+	bro *Broadcaster
+}
+
+// NewObserver instantiates a new gossiping [Observer].
+func NewObserver(
+	networkID rebro.NetworkID,
+	verifier crypto.Verifier,
+	certifier rebro.Certifier,
+	hasher rebro.Hasher,
+	decoder rebro.MessageIDDecoder,
+	quorums QuorumFn,
+	ps *pubsub.PubSub,
+	opts ...Option,
+) *Observer {
+	bro := newBroadcaster(networkID, verifier, nil, certifier, hasher, decoder, ps, opts...)
+	bro.observing = &observing{
+		quorums:     quorums,
+		finalizedCh: make(chan FinalizedRound, finalizedBufferSize),
+		pending:     make(map[uint64]struct{}),
+	}
+	bro.log = bro.log.With("mode", "observer")
+	return &Observer{bro: bro}
+}
+
+func (o *Observer) Start() error {
+	return o.bro.Start()
+}
+
+// Stop stops the [Observer] and closes the channel of finalized rounds.
+// The channel is closed once all the processing has exited, which may happen after Stop returns,
+// if stopping timed out.
+func (o *Observer) Stop(ctx context.Context) error {
+	err := o.bro.Stop(ctx)
+	go func() {
+		o.bro.queue.wait()
+		close(o.bro.observing.finalizedCh)
+	}()
+	return err
+}
+
+// Finalized provides rounds finalized by the network in the order of finalization.
+// The consumer must keep up with the rounds, as otherwise the rounds stall until dropped.
+func (o *Observer) Finalized() <-chan FinalizedRound {
+	return o.bro.observing.finalizedCh
+}
+
+// Metrics reports gossip processing statistics.
+func (o *Observer) Metrics() Metrics {
+	return o.bro.Metrics()
+}
+
+// observing keeps the state of the observer mode.
+type observing struct {
+	quorums     QuorumFn
+	finalizedCh chan FinalizedRound
+
+	// observed rounds yet to be finalized
+	pendingMu sync.Mutex
+	pending   map[uint64]struct{}
+}
+
+// observeRound starts the round on the verified signature of its includer, if the round was not
+// started yet, and awaits its finalization.
+func (bro *Broadcaster) observeRound(ctx context.Context, roundNum uint64, signer []byte) {
+	if roundNum <= bro.rounds.LatestRound() || bro.isFutureRound(roundNum) {
+		return
+	}
+
+	qcomm, err := bro.observing.quorums(roundNum)
+	if err != nil {
+		bro.log.DebugContext(ctx, "not observing round", "round", roundNum, "err", err)
+		return
+	}
+	// otherwise, anyone could push the observed rounds away from the ones of the network
+	checker, ok := qcomm.(rebro.IncluderChecker)
+	if !ok || !checker.IsIncluder(signer) {
+		bro.log.DebugContext(ctx, "not observing round of unknown signer", "round", roundNum, "signer", signer)
+		return
+	}
+
+	r, err := bro.rounds.StartRound(roundNum, qcomm, nil)
+	if err != nil {
+		// started concurrently by another gossip
+		return
+	}
+
+	bro.observing.pendingMu.Lock()
+	bro.observing.pending[roundNum] = struct{}{}
+	bro.observing.pendingMu.Unlock()
+
+	bro.stopLingering(ctx, roundNum)
+	bro.queue.enqueue(roundNum, func(ctx context.Context) {
+		err := r.Finalize(ctx)
+		if err != nil {
+			// stopped before finalization
+			return
+		}
+
+		bro.observing.pendingMu.Lock()
+		delete(bro.observing.pending, roundNum)
+		bro.observing.pendingMu.Unlock()
+
+		// the network has moved on, so preceding rounds are not going to finalize anymore
+		err = bro.stopObserved(ctx, roundNum)
+		if err != nil {
+			bro.log.ErrorContext(ctx, "stopping stale rounds", "err", err)
+		}
+
+		select {
+		case bro.observing.finalizedCh <- FinalizedRound{Round: roundNum, Quorum: qcomm}:
+		case <-ctx.Done():
+			return
+		}
+
+		err = bro.finishRound(ctx, roundNum)
+		if err != nil && !errors.Is(err, round.ErrElapsedRound) {
+			bro.log.ErrorContext(ctx, "finishing round", "round", roundNum, "err", err)
+		}
+	})
+}
+
+// stopObserved stops unfinalized observed rounds preceding the given one.
+func (bro *Broadcaster) stopObserved(ctx context.Context, before uint64) (err error) {
+	bro.observing.pendingMu.Lock()
+	var stop []uint64
+	for roundNum := range bro.observing.pending {
+		if roundNum < before {
+			stop = append(stop, roundNum)
+			delete(bro.observing.pending, roundNum)
+		}
+	}
+	bro.observing.pendingMu.Unlock()
+
+	for _, roundNum := range stop {
+		stopErr := bro.stopRound(ctx, roundNum)
+		if stopErr != nil && !errors.Is(stopErr, round.ErrElapsedRound) {
+			err = errors.Join(err, stopErr)
+		}
+	}
+	return err
+}
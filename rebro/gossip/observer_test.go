@@ -0,0 +1,111 @@
+package gossip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iykyk-syn/unison/crypto/local"
+	dagquorum "github.com/iykyk-syn/unison/dag/quorum"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+func TestObserver(t *testing.T) {
+	const nodeCount, rounds = 10, 2
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount + 1)
+	require.NoError(t, err)
+
+	signers, includers := newIncluders(t, nodeCount, 1)
+	bros := make([]*Broadcaster, nodeCount)
+	for i, h := range net.Hosts()[:nodeCount] {
+		psub := newPubSub(ctx, t, h)
+		bros[i] = NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID, psub)
+	}
+
+	quorums := func(round uint64) (rebro.QuorumCertificate, error) {
+		if round > rounds {
+			return nil, errors.New("unknown round")
+		}
+		return dagquorum.NewQuorum(includers), nil
+	}
+	psub := newPubSub(ctx, t, net.Hosts()[nodeCount])
+	obs := NewObserver(testNetworkID, local.NewVerifier(), &testCertifier{}, &testHasher{}, unmarshalmessageID, quorums, psub)
+
+	connect(ctx, t, net)
+	start(t, append(bros, obs.bro))
+
+	for round := uint64(1); round <= rounds; round++ {
+		wg, wgCtx := errgroup.WithContext(ctx)
+		for _, bro := range bros {
+			wg.Go(func() error {
+				msg, err := testMessage(round, bro.signer.ID(), randData(1024))
+				if err != nil {
+					return err
+				}
+				return bro.Broadcast(wgCtx, msg, dagquorum.NewQuorum(includers))
+			})
+		}
+		require.NoError(t, wg.Wait())
+
+		select {
+		case fin := <-obs.Finalized():
+			assert.Equal(t, round, fin.Round)
+			assertSafe(t, round, includers, fin.Quorum.(*dagquorum.Quorum))
+		case <-ctx.Done():
+			require.FailNow(t, "timeout waiting for finalized round")
+		}
+	}
+
+	assert.NotZero(t, obs.Metrics().Processed)
+
+	stopCtx, stopCancel := context.WithTimeout(ctx, time.Second)
+	defer stopCancel()
+	require.NoError(t, obs.Stop(stopCtx))
+	_, ok := <-obs.Finalized()
+	assert.False(t, ok)
+}
+
+func TestObserverStrangers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+
+	_, includers := newIncluders(t, 4, 1)
+	quorums := func(uint64) (rebro.QuorumCertificate, error) {
+		return dagquorum.NewQuorum(includers), nil
+	}
+	stranger := NewBroadcaster(testNetworkID, newLocalSigner(t), &testCertifier{}, &testHasher{}, unmarshalmessageID,
+		newPubSub(ctx, t, net.Hosts()[0]))
+	obs := NewObserver(testNetworkID, local.NewVerifier(), &testCertifier{}, &testHasher{}, unmarshalmessageID, quorums,
+		newPubSub(ctx, t, net.Hosts()[1]))
+
+	connect(ctx, t, net)
+	start(t, []*Broadcaster{stranger, obs.bro})
+
+	// gossips of non includers never start rounds
+	msg, err := testMessage(5, stranger.signer.ID(), randData(1024))
+	require.NoError(t, err)
+	broadcastCtx, broadcastCancel := context.WithCancel(ctx)
+	h, err := stranger.BroadcastAsync(broadcastCtx, msg, newQuorum(4, 3))
+	require.NoError(t, err)
+	require.Never(t, func() bool {
+		return obs.bro.rounds.LatestRound() != 0
+	}, time.Millisecond*500, time.Millisecond*10)
+
+	broadcastCancel()
+	require.ErrorIs(t, h.AwaitFinalized(ctx), rebro.ErrRoundInterrupted)
+	require.NoError(t, stranger.Stop(ctx))
+	require.NoError(t, obs.Stop(ctx))
+}
@@ -0,0 +1,142 @@
+package gossip
+
+import "time"
+
+// Parameters defines configurable parameters of the [Broadcaster].
+type Parameters struct {
+	// MaxProcessing limits the number of network gossips processed simultaneously.
+	MaxProcessing int
+	// MaxWaiting limits the number of network gossips waiting for processing.
+	// Once exceeded, the oldest waiting gossip is evicted.
+	MaxWaiting int
+	// MaxPerPeer limits the number of gossips from a single peer being either processed or waiting.
+	// Gossips exceeding the quota are dropped.
+	MaxPerPeer int
+	// ProcessingTimeout limits the time a single gossip may spend waiting and being processed.
+	ProcessingTimeout time.Duration
+	// RoundsAhead limits how far ahead of the latest started round network gossips are accepted.
+	RoundsAhead uint64
+	// MaxRoundSubscriptions limits the number of network gossips awaiting their rounds to start.
+	MaxRoundSubscriptions int
+	// RoundGracePeriod keeps finalized rounds collecting late signatures and data for the given
+	// period. Zero stops rounds right after finalization, unless StopOnNextRound is set.
+	RoundGracePeriod time.Duration
+	// StopOnNextRound keeps finalized rounds collecting late signatures and data until the next
+	// round starts. If RoundGracePeriod is set as well, rounds stop on whichever comes first.
+	StopOnNextRound bool
+	// ChunkSize is the maximum size of message data published in a single gossip.
+	// Larger data is split into chunks committed to by a Merkle root signed by the message
+	// signer. Every chunk is verified against the commitment on receipt, and the reassembled
+	// data against the MessageID hash.
+	ChunkSize int
+	// MaxChunks limits the number of chunks a single message may be split into.
+	MaxChunks int
+	// SignatureBundleSize is the number of signatures of a round published in a single gossip.
+	// One disables bundling. It is capped by the maximum bundle size peers accept.
+	SignatureBundleSize int
+	// SignatureBundleDelay is the time a signature may wait for a bundle to fill up before
+	// the bundle is published anyway.
+	SignatureBundleDelay time.Duration
+	// Versions lists wire protocol versions gossips are accepted with, each over its own topic.
+	// Gossips are published with the first version only, so upgrades go by listening to the new
+	// version first and switching to publishing with it once the whole network listens to it.
+	Versions []Version
+	// Scorer gets reported with outcomes of network gossips processing.
+	Scorer *Scorer
+}
+
+// DefaultParameters returns default [Parameters] of the [Broadcaster].
+func DefaultParameters() Parameters {
+	return Parameters{
+		MaxProcessing:         256,
+		MaxWaiting:            768,
+		MaxPerPeer:            512,
+		ProcessingTimeout:     ValidationTimeout,
+		RoundsAhead:           16,
+		MaxRoundSubscriptions: 1024,
+		ChunkSize:             256 << 10,
+		MaxChunks:             1024,
+		SignatureBundleSize:   64,
+		SignatureBundleDelay:  time.Millisecond * 10,
+		Versions:              []Version{LatestVersion},
+		Scorer:                NewScorer(),
+	}
+}
+
+// Option sets a configurable parameter of the [Broadcaster].
+type Option func(*Parameters)
+
+// WithProcessingLimits sets limits for simultaneously processed and waiting network gossips
+// together with per peer quota.
+func WithProcessingLimits(maxProcessing, maxWaiting, maxPerPeer int) Option {
+	return func(p *Parameters) {
+		p.MaxProcessing = maxProcessing
+		p.MaxWaiting = maxWaiting
+		p.MaxPerPeer = maxPerPeer
+	}
+}
+
+// WithProcessingTimeout sets the deadline for every network gossip to be processed.
+func WithProcessingTimeout(timeout time.Duration) Option {
+	return func(p *Parameters) {
+		p.ProcessingTimeout = timeout
+	}
+}
+
+// WithRoundLimits sets the window of rounds ahead of the latest started one network gossips are
+// accepted for together with the limit of gossips awaiting their rounds.
+func WithRoundLimits(roundsAhead uint64, maxSubscriptions int) Option {
+	return func(p *Parameters) {
+		p.RoundsAhead = roundsAhead
+		p.MaxRoundSubscriptions = maxSubscriptions
+	}
+}
+
+// WithRoundGracePeriod keeps finalized rounds collecting late signatures and data for the given
+// period.
+func WithRoundGracePeriod(period time.Duration) Option {
+	return func(p *Parameters) {
+		p.RoundGracePeriod = period
+	}
+}
+
+// WithStopOnNextRound keeps finalized rounds collecting late signatures and data until the next
+// round starts.
+func WithStopOnNextRound() Option {
+	return func(p *Parameters) {
+		p.StopOnNextRound = true
+	}
+}
+
+// WithChunking sets the maximum size of message data chunks and the maximum number of chunks
+// a single message may be split into.
+func WithChunking(chunkSize, maxChunks int) Option {
+	return func(p *Parameters) {
+		p.ChunkSize = chunkSize
+		p.MaxChunks = maxChunks
+	}
+}
+
+// WithSignatureBundling sets the number of signatures published in a single gossip and the time
+// they may wait for the bundle to fill up.
+func WithSignatureBundling(size int, delay time.Duration) Option {
+	return func(p *Parameters) {
+		p.SignatureBundleSize = min(size, maxSignatureBundleSize)
+		p.SignatureBundleDelay = delay
+	}
+}
+
+// WithVersions sets wire protocol versions gossips are accepted with.
+// Gossips are published with the first one.
+func WithVersions(versions ...Version) Option {
+	return func(p *Parameters) {
+		p.Versions = versions
+	}
+}
+
+// WithScorer sets the [Scorer] to report outcomes of network gossips processing to.
+func WithScorer(scorer *Scorer) Option {
+	return func(p *Parameters) {
+		p.Scorer = scorer
+	}
+}
@@ -1,20 +1,64 @@
 package gossip
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 
 	"github.com/iykyk-syn/unison/crypto"
 	"github.com/iykyk-syn/unison/rebro"
 )
 
+var (
+	// ErrNetworkExists is returned when the network is already registered with the [Orchestrator].
+	ErrNetworkExists = errors.New("network already registered")
+	// ErrUnknownNetwork is returned when the network is not registered with the [Orchestrator].
+	ErrUnknownNetwork = errors.New("unknown network")
+)
+
+// Orchestrator manages [Broadcaster]s and [Observer]s of multiple networks over a single PubSub.
+//
+// Networks are registered by their NetworkID, so that a network has a single [Broadcaster] or
+// [Observer] at most. All of them share the processing limits of the Orchestrator, so that
+// gossips of all the networks together never exceed them. Registered [Broadcaster]s join their
+// networks lazily, once requested for the first time.
 type Orchestrator struct {
-	pubsub *pubsub.PubSub
+	pubsub   *pubsub.PubSub
+	opts     []Option
+	throttle *throttle
+
+	mu       sync.Mutex
+	networks map[rebro.NetworkID]*network
 }
 
-func NewOrchestrator(ps *pubsub.PubSub) *Orchestrator {
-	return &Orchestrator{pubsub: ps}
+// network is a network registered with the [Orchestrator].
+type network struct {
+	bro *Broadcaster
+	// observer is set for observed networks only
+	observer *Observer
+	joined   bool
 }
 
+// NewOrchestrator instantiates a new [Orchestrator] applying the given options to every network.
+func NewOrchestrator(ps *pubsub.PubSub, opts ...Option) *Orchestrator {
+	params := DefaultParameters()
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return &Orchestrator{
+		pubsub:   ps,
+		opts:     opts,
+		throttle: newThrottle(params),
+		networks: make(map[rebro.NetworkID]*network),
+	}
+}
+
+// NewBroadcaster registers a new [Broadcaster] of the network and joins the network right away.
 func (o *Orchestrator) NewBroadcaster(
 	nid rebro.NetworkID,
 	signer crypto.Signer,
@@ -22,6 +66,149 @@ func (o *Orchestrator) NewBroadcaster(
 	hasher rebro.Hasher,
 	decoder rebro.MessageIDDecoder,
 ) (rebro.Broadcaster, error) {
-	bro := NewBroadcaster(nid, signer, certifier, hasher, decoder, o.pubsub)
-	return bro, bro.Start()
+	err := o.Register(nid, signer, certifier, hasher, decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	bro, err := o.Broadcaster(nid)
+	if err != nil {
+		return nil, err
+	}
+	return bro, nil
+}
+
+// Register registers a new [Broadcaster] of the network without joining the network.
+// The network is joined once the [Broadcaster] is requested with [Orchestrator.Broadcaster].
+func (o *Orchestrator) Register(
+	nid rebro.NetworkID,
+	signer crypto.Signer,
+	certifier rebro.Certifier,
+	hasher rebro.Hasher,
+	decoder rebro.MessageIDDecoder,
+) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.networks[nid]; ok {
+		return fmt.Errorf("%w: %s", ErrNetworkExists, nid)
+	}
+
+	bro := NewBroadcaster(nid, signer, certifier, hasher, decoder, o.pubsub, o.opts...)
+	bro.throttle = o.throttle
+	o.networks[nid] = &network{bro: bro}
+	return nil
+}
+
+// Broadcaster returns the [Broadcaster] of the registered network, joining the network if not
+// joined yet.
+func (o *Orchestrator) Broadcaster(nid rebro.NetworkID) (*Broadcaster, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	n, ok := o.networks[nid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, nid)
+	}
+	if n.observer != nil {
+		return nil, fmt.Errorf("network(%s) is observed", nid)
+	}
+	if n.joined {
+		return n.bro, nil
+	}
+
+	err := n.bro.Start()
+	if err != nil {
+		// the network might be joined partially, so clean it up to allow registering it anew
+		delete(o.networks, nid)
+		err = errors.Join(err, n.bro.Stop(context.Background()))
+		return nil, fmt.Errorf("joining network(%s): %w", nid, err)
+	}
+	n.joined = true
+	return n.bro, nil
+}
+
+// NewObserver registers a new [Observer] of the network and joins the network right away.
+func (o *Orchestrator) NewObserver(
+	nid rebro.NetworkID,
+	verifier crypto.Verifier,
+	certifier rebro.Certifier,
+	hasher rebro.Hasher,
+	decoder rebro.MessageIDDecoder,
+	quorums QuorumFn,
+) (*Observer, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.networks[nid]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrNetworkExists, nid)
+	}
+
+	obs := NewObserver(nid, verifier, certifier, hasher, decoder, quorums, o.pubsub, o.opts...)
+	obs.bro.throttle = o.throttle
+	err := obs.Start()
+	if err != nil {
+		err = errors.Join(err, obs.Stop(context.Background()))
+		return nil, fmt.Errorf("joining network(%s): %w", nid, err)
+	}
+
+	o.networks[nid] = &network{bro: obs.bro, observer: obs, joined: true}
+	return obs, nil
+}
+
+// List lists NetworkIDs of all the registered networks in lexicographical order.
+func (o *Orchestrator) List() []rebro.NetworkID {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	nids := make([]rebro.NetworkID, 0, len(o.networks))
+	for nid := range o.networks {
+		nids = append(nids, nid)
+	}
+	slices.Sort(nids)
+	return nids
+}
+
+// Stop leaves the network and unregisters it, so that it can be registered anew.
+func (o *Orchestrator) Stop(ctx context.Context, nid rebro.NetworkID) error {
+	o.mu.Lock()
+	n, ok := o.networks[nid]
+	delete(o.networks, nid)
+	o.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownNetwork, nid)
+	}
+
+	err := n.stop(ctx)
+	if err != nil {
+		return fmt.Errorf("stopping network(%s): %w", nid, err)
+	}
+	return nil
+}
+
+// StopAll leaves and unregisters all the networks.
+func (o *Orchestrator) StopAll(ctx context.Context) (err error) {
+	o.mu.Lock()
+	networks := o.networks
+	o.networks = make(map[rebro.NetworkID]*network)
+	o.mu.Unlock()
+
+	for nid, n := range networks {
+		stopErr := n.stop(ctx)
+		if stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("stopping network(%s): %w", nid, stopErr))
+		}
+	}
+	return err
+}
+
+func (n *network) stop(ctx context.Context) error {
+	switch {
+	case n.observer != nil:
+		return n.observer.Stop(ctx)
+	case n.joined:
+		return n.bro.Stop(ctx)
+	default:
+		return nil
+	}
 }
@@ -0,0 +1,96 @@
+package gossip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	dagquorum "github.com/iykyk-syn/unison/dag/quorum"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+func TestOrchestrator(t *testing.T) {
+	const nodeCount = 4
+	networks := []rebro.NetworkID{"net1", "net2"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount)
+	require.NoError(t, err)
+
+	signers, includers := newIncluders(t, nodeCount, 1)
+	psubs := make([]*pubsub.PubSub, nodeCount)
+	orchs := make([]*Orchestrator, nodeCount)
+	for i, h := range net.Hosts() {
+		psubs[i] = newPubSub(ctx, t, h)
+		orch := NewOrchestrator(psubs[i], WithProcessingLimits(16, 64, 64))
+		for _, nid := range networks {
+			require.NoError(t, orch.Register(nid, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID))
+		}
+		t.Cleanup(func() {
+			require.NoError(t, orch.StopAll(context.Background()))
+		})
+		orchs[i] = orch
+	}
+	connect(ctx, t, net)
+
+	orch := orchs[0]
+	assert.Equal(t, networks, orch.List())
+	err = orch.Register(networks[0], signers[0], &testCertifier{}, &testHasher{}, unmarshalmessageID)
+	assert.ErrorIs(t, err, ErrNetworkExists)
+	_, err = orch.Broadcaster("unknown")
+	assert.ErrorIs(t, err, ErrUnknownNetwork)
+
+	// networks are joined on demand only
+	assert.Empty(t, psubs[0].GetTopics())
+	bros := make(map[rebro.NetworkID][]*Broadcaster)
+	for _, nid := range networks {
+		for _, orch := range orchs {
+			bro, err := orch.Broadcaster(nid)
+			require.NoError(t, err)
+			bros[nid] = append(bros[nid], bro)
+		}
+		assert.Contains(t, psubs[0].GetTopics(), TopicName(nid, LatestVersion))
+	}
+	for _, bros := range bros {
+		for _, bro := range bros {
+			require.Eventually(t, func() bool {
+				return len(bro.topic.ListPeers()) >= nodeCount-1
+			}, time.Second*5, time.Millisecond*10)
+		}
+	}
+
+	// networks progress independently over the shared processing limits
+	wg, wgCtx := errgroup.WithContext(ctx)
+	for _, bros := range bros {
+		for _, bro := range bros {
+			wg.Go(func() error {
+				msg, err := testMessage(1, bro.signer.ID(), randData(1024))
+				if err != nil {
+					return err
+				}
+				return bro.Broadcast(wgCtx, msg, dagquorum.NewQuorum(includers))
+			})
+		}
+	}
+	require.NoError(t, wg.Wait())
+
+	// stopped networks are left and can be registered anew
+	require.NoError(t, orch.Stop(ctx, networks[0]))
+	assert.Equal(t, networks[1:], orch.List())
+	assert.NotContains(t, psubs[0].GetTopics(), TopicName(networks[0], LatestVersion))
+	_, err = orch.Broadcaster(networks[0])
+	assert.ErrorIs(t, err, ErrUnknownNetwork)
+	assert.ErrorIs(t, orch.Stop(ctx, networks[0]), ErrUnknownNetwork)
+
+	require.NoError(t, orch.Register(networks[0], signers[0], &testCertifier{}, &testHasher{}, unmarshalmessageID))
+	_, err = orch.Broadcaster(networks[0])
+	require.NoError(t, err)
+}
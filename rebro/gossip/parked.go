@@ -0,0 +1,56 @@
+package gossip
+
+import "sync"
+
+// maxParkedGossips bounds the number of gossips kept for interrupted rounds.
+const maxParkedGossips = 4096
+
+// parked keeps verified gossips of interrupted rounds until the rounds are resumed.
+// PubSub never delivers seen gossips again, so dropping them would leave a resumed round
+// without data and signatures received while it was interrupted.
+type parked struct {
+	mu     sync.Mutex
+	rounds map[uint64][]*verifiedGossip
+	count  int
+}
+
+func newParked() *parked {
+	return &parked{rounds: make(map[uint64][]*verifiedGossip)}
+}
+
+// park keeps the gossip of the interrupted round. It reports false if there is no more room.
+func (p *parked) park(round uint64, gsp *verifiedGossip) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.count >= maxParkedGossips {
+		return false
+	}
+	p.rounds[round] = append(p.rounds[round], gsp)
+	p.count++
+	return true
+}
+
+// take removes and returns all the gossips of the round.
+func (p *parked) take(round uint64) []*verifiedGossip {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	gsps := p.rounds[round]
+	delete(p.rounds, round)
+	p.count -= len(gsps)
+	return gsps
+}
+
+// drop forgets gossips of the rounds preceding the given one, which cannot be resumed anymore.
+func (p *parked) drop(before uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for round, gsps := range p.rounds {
+		if round < before {
+			delete(p.rounds, round)
+			p.count -= len(gsps)
+		}
+	}
+}
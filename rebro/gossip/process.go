@@ -6,65 +6,324 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/libp2p/go-libp2p/core/peer"
+
 	"github.com/iykyk-syn/unison/crypto"
 	"github.com/iykyk-syn/unison/rebro"
 	"github.com/iykyk-syn/unison/rebro/gossip/gossipmsg"
 	"github.com/iykyk-syn/unison/rebro/gossip/internal/round"
 )
 
-func (bro *Broadcaster) processGossip(ctx context.Context, gsp gossipmsg.Gossip) error {
-	// TODO: DOS protection idea:
-	//  * Ensure only N processGossip routines can exist
-	//  * Cancel the oldest routine, if a new one does not fit
-	//  * Ensure there is a timeout which routine
+// errFutureRound signals that the gossip belongs to a round too far ahead of the latest one.
+var errFutureRound = errors.New("future round")
+
+// maxSignatureBundleSize bounds the number of signatures in a bundle accepted from the network,
+// so that a single gossip cannot make peers verify signatures without limit.
+const maxSignatureBundleSize = 256
+
+// verifiedGossip is a gossip which passed structural and signature checks and awaits processing.
+type verifiedGossip struct {
+	id          rebro.MessageID
+	canonicalID []byte
+	// data is set for data gossips
+	data []byte
+	// signature is set for signature gossips
+	signature *crypto.Signature
+	// chunk is set for chunk gossips
+	chunk *gossipChunk
+
+	// from is the peer the gossip was received from, which is accounted for its processing
+	from peer.ID
+	// local is set for gossips published by the node itself
+	local bool
+}
+
+// verifyGossip performs cheap checks over the gossip, so that it can be processed asynchronously
+// afterward. Bundled signatures are verified and provided as separate gossips.
+func (bro *Broadcaster) verifyGossip(gsp gossipmsg.Gossip) ([]*verifiedGossip, error) {
+	if gsp.Which() == gossipmsg.Gossip_Which_signatures {
+		return bro.verifySignatures(gsp)
+	}
+
+	canonicalID, err := gsp.Id()
+	if err != nil {
+		return nil, err
+	}
 
+	id, err := bro.decodeID(canonicalID)
+	if err != nil {
+		return nil, err
+	}
+
+	vg := &verifiedGossip{id: id, canonicalID: canonicalID}
 	switch gsp.Which() {
 	case gossipmsg.Gossip_Which_data:
-		// bro.log.DebugContext(ctx, "processing data message")
-		return bro.processData(ctx, gsp)
+		vg.data, err = gsp.Data().Data()
+		if err != nil {
+			return nil, err
+		}
+
+		if err = bro.verifyHash(id, vg.data); err != nil {
+			return nil, err
+		}
+	case gossipmsg.Gossip_Which_chunk:
+		vg.chunk, err = bro.verifyChunk(id, canonicalID, gsp)
+		if err != nil {
+			return nil, err
+		}
 	case gossipmsg.Gossip_Which_signature:
-		// bro.log.DebugContext(ctx, "processing signature message")
-		return bro.processSignature(ctx, gsp)
+		signatureData, err := gsp.Signature().Signature()
+		if err != nil {
+			return nil, err
+		}
+
+		signerData, err := gsp.Signature().Signer()
+		if err != nil {
+			return nil, err
+		}
+
+		vg.signature = &crypto.Signature{
+			Body:   signatureData,
+			Signer: signerData,
+		}
+		domain := SignatureDomain(bro.networkID, Version(gsp.Version()))
+		if err := bro.verifier.Verify(domain.Tag(canonicalID), *vg.signature); err != nil {
+			return nil, fmt.Errorf("verifying signature from(%X) for round(%d): %w", signerData, id.Round(), err)
+		}
 	default:
-		return fmt.Errorf("unknown message type")
+		return nil, fmt.Errorf("unknown message type")
 	}
+
+	return []*verifiedGossip{vg}, nil
 }
 
-func (bro *Broadcaster) processData(ctx context.Context, gsp gossipmsg.Gossip) error {
-	canonicalID, err := gsp.Id()
+// verifyChunk verifies the chunk against the commitment of the message signer, so that no chunk
+// is accepted before it is proven to be a part of the signed data.
+func (bro *Broadcaster) verifyChunk(id rebro.MessageID, canonicalID []byte, gsp gossipmsg.Gossip) (*gossipChunk, error) {
+	chunk := &gossipChunk{
+		index: gsp.Chunk().Index(),
+		total: gsp.Chunk().Total(),
+	}
+	if chunk.total == 0 || int64(chunk.total) > int64(bro.params.MaxChunks) {
+		return nil, fmt.Errorf("invalid chunk total %d for MessageID(%s)", chunk.total, id.String())
+	}
+	if chunk.index >= chunk.total {
+		return nil, fmt.Errorf("chunk index %d out of total %d for MessageID(%s)",
+			chunk.index, chunk.total, id.String())
+	}
+
+	var err error
+	chunk.data, err = gsp.Chunk().Data()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	chunk.root, err = gsp.Chunk().Root()
+	if err != nil {
+		return nil, err
+	}
+	chunk.signature, err = gsp.Chunk().Signature()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := gsp.Chunk().Proof()
+	if err != nil {
+		return nil, err
+	}
+	chunk.proof = make([][]byte, proof.Len())
+	for i := range proof.Len() {
+		chunk.proof[i], err = proof.At(i)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	data, err := gsp.Data().Data()
+	if !verifyChunkProof(chunk.root, chunk.index, chunk.total, chunk.data, chunk.proof) {
+		return nil, fmt.Errorf("invalid inclusion proof of chunk %d for MessageID(%s)", chunk.index, id.String())
+	}
+	// the commitment is verified once per message
+	if bro.assembler.committed(id, chunk.total, chunk.root) {
+		return chunk, nil
+	}
+
+	domain := chunksDomain(bro.networkID, Version(gsp.Version()))
+	commitment := chunksCommitment(canonicalID, chunk.total, chunk.root)
+	err = bro.verifier.Verify(domain.Tag(commitment), crypto.Signature{Body: chunk.signature, Signer: id.Signer()})
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("verifying chunks commitment for MessageID(%s): %w", id.String(), err)
 	}
+	return chunk, nil
+}
 
+// verifySignatures verifies every signature in the bundle, which must all belong to the same round
+// and signer.
+func (bro *Broadcaster) verifySignatures(gsp gossipmsg.Gossip) ([]*verifiedGossip, error) {
+	entries, err := gsp.Signatures()
+	if err != nil {
+		return nil, err
+	}
+	if entries.Len() == 0 {
+		return nil, fmt.Errorf("empty signatures bundle")
+	}
+	if entries.Len() > maxSignatureBundleSize {
+		return nil, fmt.Errorf("signatures bundle of %d entries exceeds %d", entries.Len(), maxSignatureBundleSize)
+	}
+
+	// bundles are published by a single node for a single round, which is checked before
+	// any signature is verified
+	vgs := make([]*verifiedGossip, entries.Len())
+	for i := range entries.Len() {
+		entry := entries.At(i)
+		canonicalID, err := entry.Id()
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := bro.decodeID(canonicalID)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 && id.Round() != vgs[0].id.Round() {
+			return nil, fmt.Errorf("bundled signatures for different rounds(%d, %d)", vgs[0].id.Round(), id.Round())
+		}
+
+		signatureData, err := entry.Signature()
+		if err != nil {
+			return nil, err
+		}
+
+		signerData, err := entry.Signer()
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 && !bytes.Equal(signerData, vgs[0].signature.Signer) {
+			return nil, fmt.Errorf("bundled signatures from different signers(%X, %X)", vgs[0].signature.Signer, signerData)
+		}
+
+		signature := &crypto.Signature{
+			Body:   signatureData,
+			Signer: signerData,
+		}
+		vgs[i] = &verifiedGossip{id: id, canonicalID: canonicalID, signature: signature}
+	}
+
+	domain := SignatureDomain(bro.networkID, Version(gsp.Version()))
+	for _, vg := range vgs {
+		if err := bro.verifier.Verify(domain.Tag(vg.canonicalID), *vg.signature); err != nil {
+			return nil, fmt.Errorf("verifying signature from(%X) for round(%d): %w", vg.signature.Signer, vg.id.Round(), err)
+		}
+	}
+
+	return vgs, nil
+}
+
+// decodeID decodes and validates the canonical MessageID.
+func (bro *Broadcaster) decodeID(canonicalID []byte) (rebro.MessageID, error) {
 	id, err := bro.decoder(canonicalID)
 	if err != nil {
-		return fmt.Errorf("unmarhalling MessageID: %w", err)
+		return nil, fmt.Errorf("unmarhalling MessageID: %w", err)
 	}
 
 	if err = id.Validate(); err != nil {
-		return fmt.Errorf("validating MessageID: %w", err)
+		return nil, fmt.Errorf("validating MessageID: %w", err)
+	}
+	// checked before any signature, so that floods of future rounds are neither verified nor relayed
+	if bro.isFutureRound(id.Round()) {
+		return nil, fmt.Errorf("MessageID(%s): %w", id.String(), errFutureRound)
 	}
+	return id, nil
+}
 
-	msg := rebro.Message{
-		ID:   id,
-		Data: data,
+// isFutureRound reports whether the round is too far ahead of the latest one to be processed.
+// Observers yet to follow any round accept every round, so that they catch up with the network.
+func (bro *Broadcaster) isFutureRound(roundNum uint64) bool {
+	latest := bro.rounds.LatestRound()
+	if bro.observing != nil && latest == 0 {
+		return false
 	}
-	hash, err := bro.hasher.Hash(msg)
+	return roundNum > latest && roundNum-latest > bro.params.RoundsAhead
+}
+
+// verifyHash verifies the message data against the hash committed in the MessageID.
+func (bro *Broadcaster) verifyHash(id rebro.MessageID, data []byte) error {
+	hash, err := bro.hasher.Hash(rebro.Message{ID: id, Data: data})
 	if err != nil {
 		return fmt.Errorf("hashing Message for MessageID(%s): %w", id.String(), err)
 	}
 
-	if !bytes.Equal(hash, msg.ID.Hash()) {
+	if !bytes.Equal(hash, id.Hash()) {
 		return fmt.Errorf("computed Message hash inconsistent with MessageID(%s)", id.String())
 	}
+	return nil
+}
+
+func (bro *Broadcaster) processGossip(ctx context.Context, gsp *verifiedGossip) error {
+	if bro.observing != nil && gsp.signature != nil {
+		// signatures are verified, unlike data, so only they start rounds
+		bro.observeRound(ctx, gsp.id.Round(), gsp.signature.Signer)
+	}
+	switch {
+	case gsp.signature != nil:
+		return bro.processSignature(ctx, gsp)
+	case gsp.chunk != nil:
+		return bro.processChunk(ctx, gsp)
+	default:
+		return bro.processData(ctx, gsp)
+	}
+}
 
+// processChunk collects the chunk and processes the message data once all its chunks are received.
+func (bro *Broadcaster) processChunk(ctx context.Context, gsp *verifiedGossip) error {
+	id := gsp.id
+
+	// ensure the round is worth collecting chunks for
 	r, err := bro.rounds.GetRound(ctx, id.Round())
 	if err != nil {
+		if errors.Is(err, round.ErrInterruptedRound) {
+			bro.parkGossip(ctx, gsp)
+			return nil
+		}
+		if errors.Is(err, round.ErrElapsedRound) {
+			return nil
+		}
+		return fmt.Errorf("getting round(%d): %w", id.Round(), err)
+	}
+	if !r.IsIncluder(id.Signer()) {
+		return fmt.Errorf("chunk of MessageID(%s): %w", id.String(), round.ErrNotIncluder)
+	}
+
+	data, ok, err := bro.assembler.add(id, gsp.chunk)
+	if err != nil {
+		return fmt.Errorf("adding chunk %d of MessageID(%s): %w", gsp.chunk.index, id.String(), err)
+	}
+	if !ok {
+		return nil
+	}
+
+	if err = bro.verifyHash(id, data); err != nil {
+		// the signer committed to data not matching the MessageID
+		return err
+	}
+
+	return bro.processData(ctx, &verifiedGossip{
+		id:          id,
+		canonicalID: gsp.canonicalID,
+		data:        data,
+	})
+}
+
+func (bro *Broadcaster) processData(ctx context.Context, gsp *verifiedGossip) error {
+	id := gsp.id
+	msg := rebro.Message{
+		ID:   id,
+		Data: gsp.data,
+	}
+
+	r, err := bro.rounds.GetRound(ctx, id.Round())
+	if err != nil {
+		if errors.Is(err, round.ErrInterruptedRound) {
+			bro.parkGossip(ctx, gsp)
+			return nil
+		}
 		if errors.Is(err, round.ErrElapsedRound) {
 			return nil
 		}
@@ -92,27 +351,20 @@ func (bro *Broadcaster) processData(ctx context.Context, gsp gossipmsg.Gossip) e
 		}
 		return err
 	}
+	if bro.signer == nil {
+		// observers only collect signatures of others
+		return nil
+	}
 
-	signature, err := bro.signer.Sign(canonicalID)
+	// signatures are published with the first version only
+	domain := SignatureDomain(bro.networkID, bro.params.Versions[0])
+	signature, err := bro.signer.Sign(domain.Tag(gsp.canonicalID))
 	if err != nil {
 		return fmt.Errorf("signing MessageID(%s) for round(%d): %w", id.String(), id.Round(), err)
 	}
 
-	// TODO: Investigate reuse of the message instead of making a new one
-	// TODO: Investigate consequences of blocking here on local validation.
-	err = bro.broadcastGossip(ctx, func(gsp gossipmsg.Gossip) error {
-		gsp.SetSignature()
-		if err := gsp.SetId(canonicalID); err != nil {
-			return err
-		}
-		if err := gsp.Signature().SetSignature(signature.Body); err != nil {
-			return err
-		}
-		if err := gsp.Signature().SetSigner(signature.Signer); err != nil {
-			return err
-		}
-		return nil
-	})
+	// signatures of the round are bundled together before broadcasting
+	err = bro.bundler.add(ctx, id.Round(), bundleEntry{canonicalID: gsp.canonicalID, signature: signature})
 	if err != nil {
 		return fmt.Errorf("broadcasting signature over MessageID(%s) for round(%d): %w", id.String(), id.Round(), err)
 	}
@@ -120,53 +372,22 @@ func (bro *Broadcaster) processData(ctx context.Context, gsp gossipmsg.Gossip) e
 	return nil
 }
 
-func (bro *Broadcaster) processSignature(ctx context.Context, gsp gossipmsg.Gossip) error {
-	canonicalID, err := gsp.Id()
-	if err != nil {
-		return err
-	}
-
-	id, err := bro.decoder(canonicalID)
-	if err != nil {
-		return fmt.Errorf("unmarhalling MessageID: %w", err)
-	}
+func (bro *Broadcaster) processSignature(ctx context.Context, gsp *verifiedGossip) error {
+	id, signature := gsp.id, *gsp.signature
 
 	r, err := bro.rounds.GetRound(ctx, id.Round())
 	if err != nil {
-		if errors.Is(err, round.ErrElapsedRound) {
+		if errors.Is(err, round.ErrInterruptedRound) {
+			bro.parkGossip(ctx, gsp)
 			return nil
 		}
-		return fmt.Errorf("getting round(%d): %w", id.Round(), err)
-	}
-
-	// ensure we have the certificate before doing expensive verification
-	_, err = r.GetCertificate(ctx, id)
-	if err != nil {
-		if errors.Is(err, round.ErrClosedRound) {
+		if errors.Is(err, round.ErrElapsedRound) {
 			return nil
 		}
-		return fmt.Errorf("getting certificate(%s) for the round(%d): %w", id.String(), id.Round(), err)
-	}
-
-	signatureData, err := gsp.Signature().Signature()
-	if err != nil {
-		return err
-	}
-
-	signerData, err := gsp.Signature().Signer()
-	if err != nil {
-		return err
-	}
-
-	signature := crypto.Signature{
-		Body:   signatureData,
-		Signer: signerData,
-	}
-
-	if err := bro.signer.Verify(canonicalID, signature); err != nil {
-		return fmt.Errorf("verifying signature from(%X) for round(%d): %w", signature.Signer, id.Round(), err)
+		return fmt.Errorf("getting round(%d): %w", id.Round(), err)
 	}
 
+	// signatures are buffered by the round if the certificate is yet to come
 	err = r.AddSignature(ctx, id, signature)
 	if err != nil {
 		if errors.Is(err, round.ErrClosedRound) {
@@ -182,3 +403,25 @@ func (bro *Broadcaster) processSignature(ctx context.Context, gsp gossipmsg.Goss
 
 	return nil
 }
+
+// parkGossip keeps the gossip of the interrupted round to be replayed once the round resumes.
+func (bro *Broadcaster) parkGossip(ctx context.Context, gsp *verifiedGossip) {
+	roundNum := gsp.id.Round()
+	if !bro.parked.park(roundNum, gsp) {
+		bro.log.DebugContext(ctx, "dropping gossip of interrupted round", "round", roundNum)
+		return
+	}
+	// the round could have been resumed concurrently without seeing the gossip
+	if _, ok := bro.rounds.LookupRound(roundNum); ok {
+		bro.replayParked(roundNum)
+	}
+}
+
+// replayParked processes gossips received while the round was interrupted.
+// Replays are accounted against the peers the gossips were received from.
+func (bro *Broadcaster) replayParked(roundNum uint64) {
+	ctx := context.Background()
+	for _, gsp := range bro.parked.take(roundNum) {
+		bro.dispatchGossip(ctx, gsp)
+	}
+}
@@ -0,0 +1,170 @@
+package gossip
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/iykyk-syn/unison/rebro"
+	"github.com/iykyk-syn/unison/rebro/gossip/gossipmsg"
+)
+
+// PubSubOptions returns the recommended GossipSub options for [Broadcaster]s of the network with
+// the given committee size. Peers are scored by the network topic validation outcomes together
+// with the given [Scorer], which should be passed to the [Broadcaster] as well.
+func PubSubOptions(networkID rebro.NetworkID, committeeSize int, scorer *Scorer) []pubsub.Option {
+	queueSize := min(max(committeeSize*committeeSize, 1024), 16384)
+	return []pubsub.Option{
+		// gossips carry their own signatures
+		pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign),
+		pubsub.WithMessageIdFn(MessageID),
+		pubsub.WithGossipSubParams(GossipSubParams(committeeSize)),
+		// every includer needs every gossip, so own gossips go to all the peers right away
+		pubsub.WithFloodPublish(true),
+		// every includer signs every message, so a round produces committeeSize^2 signatures
+		// received in bursts from every mesh peer
+		pubsub.WithPeerOutboundQueueSize(queueSize),
+		pubsub.WithValidateQueueSize(queueSize),
+		pubsub.WithPeerScore(PeerScoreParams(networkID, scorer), PeerScoreThresholds()),
+	}
+}
+
+// MessageID derives PubSub message ID from the gossip content, so that duplicates of the same
+// gossip relayed by different peers are deduplicated before validation.
+//
+// The ID commits to the MessageID, the gossip kind and the signer together with the payload.
+// PubSub marks IDs as seen before validation, so committing to the payload ensures
+// invalid gossips cannot shadow valid ones with the same MessageID and signer.
+func MessageID(msg *pb.Message) string {
+	hash := sha256.New()
+	msgMsg, err := capnp.Unmarshal(msg.Data)
+	if err != nil {
+		hash.Write(msg.Data)
+		return string(hash.Sum(nil))
+	}
+
+	gsp, err := gossipmsg.ReadRootGossip(msgMsg)
+	if err != nil {
+		hash.Write(msg.Data)
+		return string(hash.Sum(nil))
+	}
+
+	var kind [2]byte
+	binary.BigEndian.PutUint16(kind[:], uint16(gsp.Which()))
+	hash.Write(kind[:])
+
+	id, _ := gsp.Id()
+	writeField(hash, id)
+	switch gsp.Which() {
+	case gossipmsg.Gossip_Which_data:
+		data, _ := gsp.Data().Data()
+		writeField(hash, data)
+	case gossipmsg.Gossip_Which_signature:
+		signer, _ := gsp.Signature().Signer()
+		signature, _ := gsp.Signature().Signature()
+		writeField(hash, signer)
+		writeField(hash, signature)
+	case gossipmsg.Gossip_Which_chunk:
+		// chunks commit to every field, as the forged commitment of one must not shadow the valid one
+		chunk := gsp.Chunk()
+		var position [8]byte
+		binary.BigEndian.PutUint32(position[:4], chunk.Index())
+		binary.BigEndian.PutUint32(position[4:], chunk.Total())
+		hash.Write(position[:])
+		data, _ := chunk.Data()
+		writeField(hash, data)
+		root, _ := chunk.Root()
+		writeField(hash, root)
+		proof, _ := chunk.Proof()
+		var count [4]byte
+		binary.BigEndian.PutUint32(count[:], uint32(proof.Len()))
+		hash.Write(count[:])
+		for i := range proof.Len() {
+			node, _ := proof.At(i)
+			writeField(hash, node)
+		}
+		signature, _ := chunk.Signature()
+		writeField(hash, signature)
+	default:
+		// bundles are unique per signer and round anyway
+		hash.Write(msg.Data)
+	}
+	return string(hash.Sum(nil))
+}
+
+// writeField writes the length prefixed field to the hash.
+func writeField(h hash.Hash, field []byte) {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(field)))
+	h.Write(size[:])
+	h.Write(field)
+}
+
+// GossipSubParams returns GossipSub parameters tuned for the given committee size.
+// Small committees get a full mesh, as every includer needs every gossip anyway,
+// while larger ones keep the mesh bounded and rely on frequent heartbeats for recovery.
+func GossipSubParams(committeeSize int) pubsub.GossipSubParams {
+	params := pubsub.DefaultGossipSubParams()
+	params.D = min(max(committeeSize-1, 1), 12)
+	params.Dlo = max(params.D*2/3, 1)
+	params.Dhi = params.D * 3 / 2
+	params.Dscore = params.D * 2 / 3
+	params.Dout = min(params.Dlo-1, params.D/2)
+	params.Dlazy = params.D
+	params.HeartbeatInterval = time.Millisecond * 500
+	return params
+}
+
+// minAppScore bounds the application specific score of the [Scorer].
+const minAppScore = -5
+
+// PeerScoreParams returns GossipSub peer scoring parameters penalizing peers for gossips rejected
+// by validation on topics of all the supported wire protocol versions and taking the [Scorer]
+// into account.
+//
+// Outcomes of asynchronous processing are reported against relayers rather than original authors
+// of gossips, so the [Scorer] is bounded to steer mesh selection without ever crossing
+// [PeerScoreThresholds].
+func PeerScoreParams(networkID rebro.NetworkID, scorer *Scorer) *pubsub.PeerScoreParams {
+	topics := make(map[string]*pubsub.TopicScoreParams, len(supportedVersions))
+	for _, version := range supportedVersions {
+		topics[TopicName(networkID, version)] = &pubsub.TopicScoreParams{
+			TopicWeight:                    1,
+			TimeInMeshQuantum:              time.Second,
+			InvalidMessageDeliveriesWeight: -100,
+			InvalidMessageDeliveriesDecay:  pubsub.ScoreParameterDecay(time.Hour),
+		}
+	}
+
+	return &pubsub.PeerScoreParams{
+		Topics: topics,
+		AppSpecificScore: func(p peer.ID) float64 {
+			return max(scorer.Score(p), minAppScore)
+		},
+		AppSpecificWeight:         1,
+		BehaviourPenaltyWeight:    -10,
+		BehaviourPenaltyThreshold: 6,
+		BehaviourPenaltyDecay:     pubsub.ScoreParameterDecay(time.Hour),
+		DecayInterval:             time.Second,
+		DecayToZero:               0.01,
+		RetainScore:               time.Hour,
+	}
+}
+
+// PeerScoreThresholds returns GossipSub peer score thresholds matching [PeerScoreParams].
+// A single invalid gossip stops gossiping with the peer, while repeated ones graylist it.
+func PeerScoreThresholds() *pubsub.PeerScoreThresholds {
+	return &pubsub.PeerScoreThresholds{
+		GossipThreshold:             -10,
+		PublishThreshold:            -100,
+		GraylistThreshold:           -500,
+		AcceptPXThreshold:           10,
+		OpportunisticGraftThreshold: 5,
+	}
+}
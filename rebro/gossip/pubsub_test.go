@@ -0,0 +1,155 @@
+package gossip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/rebro/gossip/gossipmsg"
+)
+
+func TestMessageID(t *testing.T) {
+	signer := newLocalSigner(t)
+	msg, err := testMessage(1, signer.ID(), randData(64))
+	require.NoError(t, err)
+	canonicalID, err := msg.ID.MarshalBinary()
+	require.NoError(t, err)
+	signature, err := signer.Sign(canonicalID)
+	require.NoError(t, err)
+
+	gossip := func(setter func(gossipmsg.Gossip)) *pb.Message {
+		msgMsg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		require.NoError(t, err)
+		gsp, err := gossipmsg.NewRootGossip(seg)
+		require.NoError(t, err)
+		require.NoError(t, gsp.SetId(canonicalID))
+		setter(gsp)
+
+		data, err := msgMsg.Marshal()
+		require.NoError(t, err)
+		return &pb.Message{Data: data}
+	}
+	sig := func(body []byte) func(gossipmsg.Gossip) {
+		return func(gsp gossipmsg.Gossip) {
+			gsp.SetSignature()
+			require.NoError(t, gsp.Signature().SetSigner(signature.Signer))
+			require.NoError(t, gsp.Signature().SetSignature(body))
+		}
+	}
+	chunk := func(index, total uint32, root []byte) func(gossipmsg.Gossip) {
+		return func(gsp gossipmsg.Gossip) {
+			gsp.SetChunk()
+			gsp.Chunk().SetIndex(index)
+			gsp.Chunk().SetTotal(total)
+			require.NoError(t, gsp.Chunk().SetData(msg.Data))
+			require.NoError(t, gsp.Chunk().SetRoot(root))
+			require.NoError(t, gsp.Chunk().SetSignature(signature.Body))
+		}
+	}
+	data := func(gsp gossipmsg.Gossip) {
+		gsp.SetData()
+		require.NoError(t, gsp.Data().SetData(msg.Data))
+	}
+
+	// duplicates share the ID
+	assert.Equal(t, MessageID(gossip(sig(signature.Body))), MessageID(gossip(sig(signature.Body))))
+
+	// while gossips of different kinds, chunks, forged signatures and commitments do not
+	root := randData(32)
+	ids := map[string]struct{}{
+		MessageID(gossip(sig(signature.Body))):          {},
+		MessageID(gossip(sig(make([]byte, 64)))):        {},
+		MessageID(gossip(chunk(0, 2, root))):            {},
+		MessageID(gossip(chunk(1, 2, root))):            {},
+		MessageID(gossip(chunk(0, 3, root))):            {},
+		MessageID(gossip(chunk(0, 2, randData(32)))):    {},
+		MessageID(gossip(data)):                         {},
+		MessageID(&pb.Message{Data: []byte("garbage")}): {},
+	}
+	assert.Len(t, ids, 8)
+}
+
+func TestMessageIDForgedChunk(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	signer, stranger := newLocalSigner(t), newLocalSigner(t)
+	bro := NewBroadcaster(testNetworkID, signer, &testCertifier{}, &testHasher{}, unmarshalmessageID, nil)
+	t.Cleanup(func() { require.NoError(t, bro.queue.stop(ctx)) })
+
+	msg, err := testMessage(1, signer.ID(), randData(1000))
+	require.NoError(t, err)
+	canonicalID, err := msg.ID.MarshalBinary()
+	require.NoError(t, err)
+	domain := chunksDomain(testNetworkID, LatestVersion)
+
+	chunk := func(signer crypto.Signer, data []byte) *pubsub.Message {
+		parts := splitData(data, 300)
+		total := uint32(len(parts))
+		root, proofs := merkleTree(parts)
+		signature, err := signer.Sign(domain.Tag(chunksCommitment(canonicalID, total, root)))
+		require.NoError(t, err)
+
+		msgMsg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		require.NoError(t, err)
+		gsp, err := gossipmsg.NewRootGossip(seg)
+		require.NoError(t, err)
+		require.NoError(t, gsp.SetId(canonicalID))
+		gsp.SetVersion(uint16(LatestVersion))
+		gsp.SetChunk()
+		gsp.Chunk().SetIndex(0)
+		gsp.Chunk().SetTotal(total)
+		// the forged chunk carries the same data, so that only its commitment differs
+		require.NoError(t, gsp.Chunk().SetData(msg.Data[:300]))
+		require.NoError(t, gsp.Chunk().SetRoot(root))
+		require.NoError(t, gsp.Chunk().SetSignature(signature.Body))
+		list, err := gsp.Chunk().NewProof(int32(len(proofs[0])))
+		require.NoError(t, err)
+		for i, hash := range proofs[0] {
+			require.NoError(t, list.Set(i, hash))
+		}
+
+		raw, err := msgMsg.Marshal()
+		require.NoError(t, err)
+		return &pubsub.Message{Message: &pb.Message{Data: raw}}
+	}
+
+	forged := append(append([]byte(nil), msg.Data[:300]...), randData(700)...)
+	// PubSub marks IDs as seen before validation
+	seen := make(map[string]struct{})
+	deliver := func(gossip *pubsub.Message) (pubsub.ValidationResult, bool) {
+		id := MessageID(gossip.Message)
+		if _, ok := seen[id]; ok {
+			return pubsub.ValidationIgnore, false
+		}
+		seen[id] = struct{}{}
+		return bro.deliverGossip(ctx, LatestVersion, "peer", gossip), true
+	}
+
+	// the chunk committed by a stranger arrives first and gets rejected
+	res, ok := deliver(chunk(stranger, forged))
+	require.True(t, ok)
+	assert.Equal(t, pubsub.ValidationReject, res)
+	// while the valid one is not shadowed by it
+	res, ok = deliver(chunk(signer, msg.Data))
+	require.True(t, ok)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+}
+
+func TestGossipSubParams(t *testing.T) {
+	for _, size := range []int{1, 4, 10, 100, 1000} {
+		params := GossipSubParams(size)
+		assert.LessOrEqual(t, params.D, max(size-1, 1))
+		assert.LessOrEqual(t, params.Dlo, params.D)
+		assert.GreaterOrEqual(t, params.Dhi, params.D)
+		assert.Less(t, params.Dout, params.Dlo)
+		assert.LessOrEqual(t, params.Dout, params.D/2)
+	}
+}
@@ -0,0 +1,113 @@
+package gossip
+
+import (
+	"context"
+	"sync"
+)
+
+// queue runs asynchronous gossip processing tasks grouped by rounds.
+// Tasks of a round get canceled once the round is dropped.
+type queue struct {
+	mu     sync.Mutex
+	rounds map[uint64]*roundQueue
+	wg     sync.WaitGroup
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// roundQueue keeps in-progress processing tasks of a single round.
+type roundQueue struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	tasks  int
+}
+
+func newQueue() *queue {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &queue{
+		rounds: make(map[uint64]*roundQueue),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// enqueue runs the task for the round asynchronously and reports whether it was accepted.
+// The given context is canceled once the round is dropped or the queue is stopped.
+func (q *queue) enqueue(round uint64, task func(context.Context)) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.ctx.Err() != nil {
+		return false
+	}
+
+	rq, ok := q.rounds[round]
+	if !ok {
+		ctx, cancel := context.WithCancel(q.ctx)
+		rq = &roundQueue{ctx: ctx, cancel: cancel}
+		q.rounds[round] = rq
+	}
+	rq.tasks++
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		task(rq.ctx)
+		q.done(round, rq)
+	}()
+	return true
+}
+
+// done finishes the task of the round, cleaning up the round once it has no more tasks.
+func (q *queue) done(round uint64, rq *roundQueue) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	rq.tasks--
+	if rq.tasks > 0 {
+		return
+	}
+	rq.cancel()
+	// the round could have been dropped and recreated already
+	if q.rounds[round] == rq {
+		delete(q.rounds, round)
+	}
+}
+
+// drop cancels all the in-progress tasks of the round.
+func (q *queue) drop(round uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	rq, ok := q.rounds[round]
+	if !ok {
+		return
+	}
+	rq.cancel()
+	delete(q.rounds, round)
+}
+
+// wait waits for all the tasks to terminate.
+func (q *queue) wait() {
+	q.wg.Wait()
+}
+
+// stop cancels all the tasks and waits for them to terminate.
+func (q *queue) stop(ctx context.Context) error {
+	q.mu.Lock()
+	q.cancel()
+	q.mu.Unlock()
+
+	doneCh := make(chan struct{})
+	go func() {
+		q.wait()
+		close(doneCh)
+	}()
+
+	select {
+	case <-doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,82 @@
+package gossip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	q := newQueue()
+	block := func(ctx context.Context) {
+		<-ctx.Done()
+	}
+
+	canceled := make(chan uint64, 3)
+	for _, round := range []uint64{1, 1, 2} {
+		q.enqueue(round, func(ctx context.Context) {
+			block(ctx)
+			canceled <- round
+		})
+	}
+
+	// dropping the round cancels only its tasks
+	q.drop(1)
+	assert.EqualValues(t, 1, <-canceled)
+	assert.EqualValues(t, 1, <-canceled)
+	select {
+	case <-canceled:
+		t.Fatal("task of another round got canceled")
+	case <-time.After(time.Millisecond * 10):
+	}
+
+	// finished tasks clean up the round
+	done := make(chan struct{})
+	q.enqueue(3, func(context.Context) { close(done) })
+	<-done
+	require.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		_, ok := q.rounds[3]
+		return !ok
+	}, time.Second, time.Millisecond)
+
+	// stopping cancels the rest and awaits termination
+	err := q.stop(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, <-canceled)
+
+	// no tasks are run once stopped
+	assert.False(t, q.enqueue(4, func(context.Context) { t.Fatal("task run after stop") }))
+	require.NoError(t, q.stop(ctx))
+}
+
+func TestScorer(t *testing.T) {
+	s := NewScorer()
+	assert.Zero(t, s.Score("peer"))
+
+	for range 1000 {
+		s.record("peer", true)
+	}
+	assert.Equal(t, maxValidGossipScore, s.Score("peer"))
+
+	s.record("peer", false)
+	s.record("peer", false)
+	assert.Less(t, s.Score("peer"), 0.0)
+
+	// stats of peers gone quiet expire and get pruned on the next sweep
+	s.record("other", false)
+	s.peers["peer"].last = time.Now().Add(-scoreRetention - time.Second)
+	assert.Zero(t, s.Score("peer"))
+	s.pruned = time.Time{}
+	s.record("other", false)
+	assert.NotContains(t, s.peers, peer.ID("peer"))
+	assert.Contains(t, s.peers, peer.ID("other"))
+}
@@ -0,0 +1,92 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// validGossipWeight rewards a peer for every gossip processed successfully.
+	validGossipWeight = 0.01
+	// maxValidGossipScore caps the reward, so that peers cannot farm the score.
+	maxValidGossipScore = 1.0
+	// invalidGossipWeight penalizes a peer for every gossip failed processing.
+	invalidGossipWeight = -1.0
+	// scoreRetention is the time stats of a peer are kept after its last reported gossip,
+	// matching the time GossipSub retains scores of disconnected peers.
+	scoreRetention = time.Hour
+	// pruneInterval is the minimal time between sweeps of expired stats.
+	pruneInterval = time.Minute
+)
+
+// Scorer scores peers by outcomes of asynchronous processing of their gossips.
+//
+// Gossips are accepted by PubSub validation only after cheap structural and signature checks, so
+// the outcomes of the rest of processing are reported to the Scorer instead. Its [Scorer.Score] is
+// meant to be used as the application specific score of GossipSub peer scoring.
+// Stats of peers not reported for [scoreRetention] are forgotten, so that the Scorer does not grow
+// with every peer ever seen.
+type Scorer struct {
+	mu     sync.Mutex
+	peers  map[peer.ID]*peerStats
+	pruned time.Time
+}
+
+type peerStats struct {
+	valid, invalid uint64
+	// last is the time of the last reported gossip
+	last time.Time
+}
+
+// NewScorer instantiates a new [Scorer].
+func NewScorer() *Scorer {
+	return &Scorer{peers: make(map[peer.ID]*peerStats)}
+}
+
+// Score reports the score of the given peer.
+func (s *Scorer) Score(p peer.ID) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.peers[p]
+	if !ok || time.Since(stats.last) > scoreRetention {
+		return 0
+	}
+	return min(float64(stats.valid)*validGossipWeight, maxValidGossipScore) +
+		float64(stats.invalid)*invalidGossipWeight
+}
+
+// record reports the processing outcome of the gossip from the given peer.
+func (s *Scorer) record(p peer.ID, valid bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.pruned) >= pruneInterval {
+		s.prune(now)
+	}
+
+	stats, ok := s.peers[p]
+	if !ok || now.Sub(stats.last) > scoreRetention {
+		stats = &peerStats{}
+		s.peers[p] = stats
+	}
+	stats.last = now
+	if valid {
+		stats.valid++
+	} else {
+		stats.invalid++
+	}
+}
+
+// prune forgets stats of peers not reported for [scoreRetention].
+func (s *Scorer) prune(now time.Time) {
+	for p, stats := range s.peers {
+		if now.Sub(stats.last) > scoreRetention {
+			delete(s.peers, p)
+		}
+	}
+	s.pruned = now
+}
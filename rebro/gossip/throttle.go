@@ -0,0 +1,182 @@
+package gossip
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+var (
+	// errThrottled is returned when a peer exceeds its processing quota.
+	errThrottled = errors.New("peer exceeded processing quota")
+	// errEvicted is returned when a waiting gossip is evicted in favour of a newer one.
+	errEvicted = errors.New("evicted from processing queue")
+)
+
+// priority of the gossip processing. Lower value takes precedence.
+type priority uint8
+
+const (
+	prioritySignature priority = iota
+	priorityData
+	priorityCount
+)
+
+// throttle bounds simultaneous gossip processing routines.
+//
+// Gossips that do not fit are queued by priority, so that cheaper signatures are processed
+// before data. Once the queue is full, the oldest waiting gossip is evicted, preferring the lower
+// priority ones. Every peer has its quota of gossips being processed or waiting, which is taken
+// on admission before any processing routine is spawned.
+//
+// A throttle may be shared by multiple [Broadcaster]s, so that they share the processing budget.
+// Dropped gossips are accounted in metrics of the [Broadcaster] they belong to.
+type throttle struct {
+	maxActive, maxWaiting, maxPerPeer int
+
+	mu      sync.Mutex
+	active  int
+	perPeer map[peer.ID]int
+	waiting [priorityCount]*list.List
+}
+
+// waiter is a gossip routine waiting for its turn.
+type waiter struct {
+	prio    priority
+	elem    *list.Element
+	err     error
+	ready   chan struct{}
+	metrics *metrics
+}
+
+func newThrottle(params Parameters) *throttle {
+	t := &throttle{
+		maxActive:  params.MaxProcessing,
+		maxWaiting: params.MaxWaiting,
+		maxPerPeer: params.MaxPerPeer,
+		perPeer:    make(map[peer.ID]int),
+	}
+	for i := range t.waiting {
+		t.waiting[i] = list.New()
+	}
+	return t
+}
+
+// admit takes the quota of the given peer for the gossip, accounting the gossip in the given
+// metrics if the quota is exceeded. Admitted gossips must either [throttle.acquire] a processing
+// slot or be dismissed.
+func (t *throttle) admit(from peer.ID, metrics *metrics) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.perPeer[from] >= t.maxPerPeer {
+		metrics.throttled.Add(1)
+		return errThrottled
+	}
+	t.perPeer[from]++
+	return nil
+}
+
+// dismiss gives the quota of the admitted gossip back without processing it.
+func (t *throttle) dismiss(from peer.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.leave(from)
+}
+
+// acquire awaits a processing slot for the admitted gossip from the given peer, accounting
+// the gossip in the given metrics if dropped. The returned release func must be called once
+// processing is done, while the quota is given back on errors.
+func (t *throttle) acquire(ctx context.Context, from peer.ID, prio priority, metrics *metrics) (func(), error) {
+	t.mu.Lock()
+	release := func() {
+		t.release(from)
+	}
+	if t.active < t.maxActive {
+		t.active++
+		t.mu.Unlock()
+		return release, nil
+	}
+
+	w := &waiter{prio: prio, ready: make(chan struct{}), metrics: metrics}
+	w.elem = t.waiting[prio].PushBack(w)
+	if t.waitingLen() > t.maxWaiting {
+		t.evict()
+	}
+	t.mu.Unlock()
+
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		t.mu.Lock()
+		select {
+		case <-w.ready:
+			// we were granted a slot or evicted concurrently, so the waiter is not in the queue
+		default:
+			t.waiting[prio].Remove(w.elem)
+			w.err = ctx.Err()
+			metrics.expired.Add(1)
+		}
+		t.mu.Unlock()
+	}
+
+	if w.err != nil {
+		t.mu.Lock()
+		t.leave(from)
+		t.mu.Unlock()
+		return nil, w.err
+	}
+	return release, nil
+}
+
+// release frees the processing slot and passes it to the next waiter, if any.
+func (t *throttle) release(from peer.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.leave(from)
+	for _, queue := range t.waiting {
+		if queue.Len() == 0 {
+			continue
+		}
+		// the slot is handed over, so active count stays the same
+		w := queue.Remove(queue.Front()).(*waiter)
+		close(w.ready)
+		return
+	}
+	t.active--
+}
+
+// evict drops the oldest waiter of the lowest priority.
+func (t *throttle) evict() {
+	for prio := priorityCount - 1; ; prio-- {
+		queue := t.waiting[prio]
+		if queue.Len() > 0 {
+			w := queue.Remove(queue.Front()).(*waiter)
+			w.err = errEvicted
+			close(w.ready)
+			w.metrics.evicted.Add(1)
+			return
+		}
+		if prio == 0 {
+			return
+		}
+	}
+}
+
+func (t *throttle) leave(from peer.ID) {
+	t.perPeer[from]--
+	if t.perPeer[from] == 0 {
+		delete(t.perPeer, from)
+	}
+}
+
+func (t *throttle) waitingLen() (n int) {
+	for _, queue := range t.waiting {
+		n += queue.Len()
+	}
+	return n
+}
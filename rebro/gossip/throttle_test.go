@@ -0,0 +1,110 @@
+package gossip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	metrics := &metrics{}
+	thr := newThrottle(Parameters{MaxProcessing: 1, MaxWaiting: 2, MaxPerPeer: 2})
+
+	// occupy the only processing slot
+	require.NoError(t, thr.admit("peer1", metrics))
+	release, err := thr.acquire(ctx, "peer1", priorityData, metrics)
+	require.NoError(t, err)
+
+	order := make(chan priority, 2)
+	errs := make(chan error, 3)
+	wait := func(from peer.ID, prio priority) {
+		require.NoError(t, thr.admit(from, metrics))
+		release, err := thr.acquire(ctx, from, prio, metrics)
+		errs <- err
+		if err == nil {
+			order <- prio
+			release()
+		}
+	}
+	go wait("peer1", priorityData)
+	waitQueued(t, thr, 1)
+	go wait("peer2", prioritySignature)
+	waitQueued(t, thr, 2)
+
+	// the peer exceeded its quota, so the gossip is not admitted
+	require.ErrorIs(t, thr.admit("peer1", metrics), errThrottled)
+
+	// the queue overflows and the oldest data gossip is evicted
+	go wait("peer3", priorityData)
+	require.ErrorIs(t, <-errs, errEvicted)
+
+	// waiters are served by priority
+	release()
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+	assert.Equal(t, prioritySignature, <-order)
+	assert.Equal(t, priorityData, <-order)
+
+	snapshot := metrics.snapshot()
+	assert.EqualValues(t, 1, snapshot.Evicted)
+	assert.EqualValues(t, 1, snapshot.Throttled)
+
+	// dismissed gossips give the quota back
+	require.NoError(t, thr.admit("peer4", metrics))
+	thr.dismiss("peer4")
+	assert.Empty(t, thr.perPeer)
+}
+
+func TestThrottleExpiry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	metrics := &metrics{}
+	thr := newThrottle(Parameters{MaxProcessing: 1, MaxWaiting: 1, MaxPerPeer: 2})
+
+	require.NoError(t, thr.admit("peer", metrics))
+	release, err := thr.acquire(ctx, "peer", priorityData, metrics)
+	require.NoError(t, err)
+
+	require.NoError(t, thr.admit("peer", metrics))
+	waitCtx, waitCancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer waitCancel()
+	_, err = thr.acquire(waitCtx, "peer", prioritySignature, metrics)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualValues(t, 1, metrics.snapshot().Expired)
+
+	release()
+	assert.Zero(t, thr.active)
+	assert.Empty(t, thr.perPeer)
+}
+
+func TestThrottleParked(t *testing.T) {
+	bro := NewBroadcaster(testNetworkID, newLocalSigner(t), &testCertifier{}, &testHasher{}, unmarshalmessageID, nil,
+		WithProcessingLimits(1, 1, 1))
+
+	msg, err := testMessage(1, []byte("signer"), randData(32))
+	require.NoError(t, err)
+	gsp := &verifiedGossip{id: msg.ID, data: msg.Data, from: "peer"}
+
+	// replays of parked gossips are accounted against the peer they were received from
+	require.NoError(t, bro.throttle.admit("peer", bro.metrics))
+	require.True(t, bro.parked.park(1, gsp))
+	bro.replayParked(1)
+	assert.EqualValues(t, 1, bro.metrics.snapshot().Throttled)
+	assert.Empty(t, bro.queue.rounds)
+}
+
+func waitQueued(t *testing.T, thr *throttle, n int) {
+	require.Eventually(t, func() bool {
+		thr.mu.Lock()
+		defer thr.mu.Unlock()
+		return thr.waitingLen() == n
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,67 @@
+package gossip
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/iykyk-syn/unison/crypto"
+	"github.com/iykyk-syn/unison/rebro"
+)
+
+// Version is the version of the gossiping wire protocol.
+//
+// Every version is gossiped over its own PubSub topic, so that nodes speaking incompatible
+// versions never split the network silently. Instead, upgrading nodes listen to both versions,
+// while publishing with the old one until the whole network is upgraded.
+type Version uint16
+
+const (
+	// V1 is the initial version of the wire protocol.
+	V1 Version = 1
+	// LatestVersion is the latest version of the wire protocol.
+	LatestVersion = V1
+)
+
+// supportedVersions lists all the wire protocol versions the [Broadcaster] can speak.
+var supportedVersions = []Version{V1}
+
+// Supported reports whether the [Broadcaster] can speak the version.
+func (v Version) Supported() bool {
+	return slices.Contains(supportedVersions, v)
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("v%d", uint16(v))
+}
+
+// TopicName returns the name of PubSub topic gossips of the network are published to with the
+// given wire protocol version.
+func TopicName(networkID rebro.NetworkID, version Version) string {
+	return fmt.Sprintf("/%s/rebro/%s", networkID, version)
+}
+
+// signatureKind is the kind of signatures over canonical MessageIDs.
+const signatureKind = "rebro/gossip/signature"
+
+// SignatureDomain returns the [crypto.Domain] of signatures over canonical MessageIDs of the
+// network gossiped with the given wire protocol version.
+func SignatureDomain(networkID rebro.NetworkID, version Version) crypto.Domain {
+	return crypto.Domain{
+		Network: networkID.String(),
+		Version: uint16(version),
+		Kind:    signatureKind,
+	}
+}
+
+// chunksKind is the kind of signatures over commitments to chunks of message data.
+const chunksKind = "rebro/gossip/chunks"
+
+// chunksDomain returns the [crypto.Domain] of signatures over commitments to chunks of message
+// data of the network gossiped with the given wire protocol version.
+func chunksDomain(networkID rebro.NetworkID, version Version) crypto.Domain {
+	return crypto.Domain{
+		Network: networkID.String(),
+		Version: uint16(version),
+		Kind:    chunksKind,
+	}
+}
@@ -0,0 +1,115 @@
+package gossip
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	dagquorum "github.com/iykyk-syn/unison/dag/quorum"
+	"github.com/iykyk-syn/unison/rebro/gossip/gossipmsg"
+)
+
+func TestBroadcasterVersions(t *testing.T) {
+	const (
+		nodeCount = 10
+		v2        = LatestVersion + 1
+	)
+
+	// pretend the next version is supported to upgrade to it
+	supported := supportedVersions
+	supportedVersions = append(slices.Clone(supportedVersions), v2)
+	t.Cleanup(func() {
+		supportedVersions = supported
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(nodeCount + 1)
+	require.NoError(t, err)
+
+	// the extra node publishes malformed gossips only
+	topic, err := newPubSub(ctx, t, net.Hosts()[nodeCount]).Join(TopicName(testNetworkID, LatestVersion))
+	require.NoError(t, err)
+
+	// half of the network has already switched to publishing with the new version,
+	// while the rest still publishes with the old one
+	signers, includers := newIncluders(t, nodeCount, 1)
+	bros := make([]*Broadcaster, nodeCount)
+	for i, h := range net.Hosts()[:nodeCount] {
+		versions := WithVersions(LatestVersion, v2)
+		if i%2 == 0 {
+			versions = WithVersions(v2, LatestVersion)
+		}
+
+		psub := newPubSub(ctx, t, h)
+		bros[i] = NewBroadcaster(testNetworkID, signers[i], &testCertifier{}, &testHasher{}, unmarshalmessageID, psub, versions)
+	}
+
+	connect(ctx, t, net)
+	start(t, bros)
+	for _, bro := range bros {
+		require.Eventually(t, func() bool {
+			return len(bro.topics[LatestVersion].ListPeers()) >= nodeCount-1 &&
+				len(bro.topics[v2].ListPeers()) >= nodeCount-1
+		}, time.Second*5, time.Millisecond*10)
+	}
+
+	wg, wgCtx := errgroup.WithContext(ctx)
+	for _, bro := range bros {
+		wg.Go(func() error {
+			msg, err := testMessage(1, bro.signer.ID(), randData(1024))
+			if err != nil {
+				return err
+			}
+			return bro.Broadcast(wgCtx, msg, dagquorum.NewQuorum(includers))
+		})
+	}
+	require.NoError(t, wg.Wait())
+
+	// gossips of a version other than the one of the topic are rejected
+	msgMsg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	require.NoError(t, err)
+	gsp, err := gossipmsg.NewRootGossip(seg)
+	require.NoError(t, err)
+	gsp.SetVersion(uint16(v2 + 1))
+	gsp.SetData()
+	data, err := msgMsg.Marshal()
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(topic.ListPeers()) >= nodeCount
+	}, time.Second*5, time.Millisecond*10)
+	require.NoError(t, topic.Publish(ctx, data))
+	for _, bro := range bros {
+		require.Eventually(t, func() bool {
+			return bro.Metrics().UnknownVersion == 1
+		}, time.Second*5, time.Millisecond*10)
+	}
+}
+
+func TestBroadcasterUnsupportedVersion(t *testing.T) {
+	net, err := mocknet.FullMeshLinked(1)
+	require.NoError(t, err)
+	psub := newPubSub(context.Background(), t, net.Hosts()[0])
+
+	for _, versions := range [][]Version{{}, {LatestVersion + 1}, {LatestVersion, LatestVersion}} {
+		bro := NewBroadcaster(testNetworkID, newTestSigner(), &testCertifier{}, &testHasher{},
+			unmarshalmessageID, psub, WithVersions(versions...))
+		assert.Error(t, bro.Start(), versions)
+	}
+}
+
+func TestTopicName(t *testing.T) {
+	assert.Equal(t, "/test/rebro/v1", TopicName("test", V1))
+	assert.NotEqual(t, TopicName("test", V1), TopicName("test", V1+1))
+	assert.True(t, LatestVersion.Supported())
+	assert.False(t, Version(0).Supported())
+}
@@ -10,8 +10,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/iykyk-syn/unison/crypto"
 	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/crypto/secp256k1"
 	"github.com/iykyk-syn/unison/dag/quorum"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -23,21 +26,16 @@ var bootstrapProtocol protocol.ID = "/bootstrap"
 type Service struct {
 	host host.Host
 
-	selfPublicKey ed25519.PublicKey
+	selfPublicKey crypto.PubKey
 	networkSize   int
 
 	log *slog.Logger
 }
 
-func NewService(localPublicKey []byte, host host.Host, networkSize int) *Service {
-	key, err := ed25519.BytesToPubKey(localPublicKey)
-	if err != nil {
-		panic(err)
-	}
-
+func NewService(localPublicKey crypto.PubKey, host host.Host, networkSize int) *Service {
 	return &Service{
 		host:          host,
-		selfPublicKey: key,
+		selfPublicKey: localPublicKey,
 		networkSize:   networkSize,
 		log:           slog.With("module", "bootstrap-svc"),
 	}
@@ -169,15 +167,9 @@ func (serv *Service) GetMembers(uint64) (*quorum.Includers, error) {
 	incls = append(incls, quorum.NewIncluder(serv.selfPublicKey, defaultStake))
 
 	for _, p := range peers {
-		keyWrap := store.PubKey(p)
-		keyBytes, err := keyWrap.Raw()
-		if err != nil {
-			return nil, err
-		}
-
-		key, err := ed25519.BytesToPubKey(keyBytes)
+		key, err := toPubKey(store.PubKey(p))
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("peer %s: %w", p, err)
 		}
 
 		incls = append(incls, quorum.NewIncluder(key, defaultStake))
@@ -185,3 +177,20 @@ func (serv *Service) GetMembers(uint64) (*quorum.Includers, error) {
 
 	return quorum.NewIncludersSet(incls), nil
 }
+
+// toPubKey converts the libp2p identity key of a peer into the key it signs with.
+func toPubKey(keyWrap libp2pcrypto.PubKey) (crypto.PubKey, error) {
+	keyBytes, err := keyWrap.Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	switch keyWrap.Type() {
+	case libp2pcrypto.Ed25519:
+		return ed25519.BytesToPubKey(keyBytes)
+	case libp2pcrypto.Secp256k1:
+		return secp256k1.BytesToPubKey(keyBytes)
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", keyWrap.Type())
+	}
+}
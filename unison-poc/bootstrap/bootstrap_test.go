@@ -7,12 +7,16 @@ import (
 	"testing"
 	"time"
 
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	bhost "github.com/libp2p/go-libp2p/p2p/host/blank"
 	swarmt "github.com/libp2p/go-libp2p/p2p/net/swarm/testing"
 	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iykyk-syn/unison/crypto/ed25519"
+	"github.com/iykyk-syn/unison/crypto/secp256k1"
 )
 
 func TestBootstrap(t *testing.T) {
@@ -24,7 +28,7 @@ func TestBootstrap(t *testing.T) {
 	t.Cleanup(cancel)
 
 	hosts := make([]host.Host, nodeCount)
-	keys := make([][]byte, nodeCount)
+	keys := make([]ed25519.PublicKey, nodeCount)
 	for i := range nodeCount {
 		randKey := make([]byte, 32)
 		rand.Reader.Read(randKey) //nolint: errcheck
@@ -73,3 +77,28 @@ func testHost(t *testing.T, key []byte) host.Host {
 	id.Start()
 	return h
 }
+
+func TestToPubKey(t *testing.T) {
+	_, edKey, err := libp2pcrypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	_, secpKey, err := libp2pcrypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+
+	for keyType, keyWrap := range map[string]libp2pcrypto.PubKey{
+		ed25519.KeyType:   edKey,
+		secp256k1.KeyType: secpKey,
+	} {
+		key, err := toPubKey(keyWrap)
+		require.NoError(t, err)
+		assert.Equal(t, keyType, key.Type())
+
+		raw, err := keyWrap.Raw()
+		require.NoError(t, err)
+		assert.Equal(t, raw, key.Bytes())
+	}
+
+	_, rsaKey, err := libp2pcrypto.GenerateRSAKeyPair(2048, rand.Reader)
+	require.NoError(t, err)
+	_, err = toPubKey(rsaKey)
+	assert.Error(t, err)
+}
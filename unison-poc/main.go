@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -26,8 +27,10 @@ import (
 	"github.com/iykyk-syn/unison/crypto"
 	"github.com/iykyk-syn/unison/crypto/ed25519"
 	"github.com/iykyk-syn/unison/crypto/local"
+	"github.com/iykyk-syn/unison/crypto/secp256k1"
 	"github.com/iykyk-syn/unison/dag"
 	"github.com/iykyk-syn/unison/dag/block"
+	"github.com/iykyk-syn/unison/dag/light"
 	"github.com/iykyk-syn/unison/dag/quorum"
 	"github.com/iykyk-syn/unison/rebro"
 	"github.com/iykyk-syn/unison/rebro/gossip"
@@ -43,6 +46,7 @@ var (
 	batchSize      int
 	batchTime      time.Duration
 	networkSize    int
+	keyType        string
 )
 
 func init() {
@@ -62,6 +66,9 @@ func init() {
 	flag.IntVar(&networkSize, "network-size", 0,
 		"Expected network size to wait for before starting the network. SKips if 0",
 	)
+	flag.StringVar(&keyType, "key-type", ed25519.KeyType,
+		"Key type of a newly generated identity: ed25519 or secp256k1",
+	)
 	flag.Parse()
 
 	slog.SetLogLoggerLevel(slog.LevelDebug)
@@ -85,7 +92,17 @@ func run(ctx context.Context) error {
 		return err
 	}
 
-	signer, err := local.NewSigner(privKey)
+	localSigner, err := local.NewSigner(privKey)
+	if err != nil {
+		return err
+	}
+	// nodes are identified by self-describing keys, so that their signatures are verified
+	// by the scheme of the signer and schemes can coexist in the network
+	signer, err := crypto.NewDescribedSigner(localSigner, privKey.PubKey().Type())
+	if err != nil {
+		return err
+	}
+	signerID, err := crypto.Describe(privKey.PubKey())
 	if err != nil {
 		return err
 	}
@@ -124,12 +141,13 @@ func run(ctx context.Context) error {
 	}
 	fmt.Println()
 
-	pSub, err := pubsub.NewFloodSub(ctx, host)
+	scorer := gossip.NewScorer()
+	pSub, err := pubsub.NewGossipSub(ctx, host, gossip.PubSubOptions(networkID, networkSize, scorer)...)
 	if err != nil {
 		return err
 	}
 
-	bootstrap := bootstrap2.NewService(signer.ID(), host, networkSize)
+	bootstrap := bootstrap2.NewService(privKey.PubKey(), host, networkSize)
 	if isBootstrapper {
 		err := bootstrap.Serve(ctx)
 		if err != nil {
@@ -154,13 +172,24 @@ func run(ctx context.Context) error {
 
 	pool := bapl.NewMemPool()
 	defer pool.Close()
-	mcastPool := bapl.NewMulticastPool(pool, host, host.Network().Peers, signer, &batchVerifier{})
+	mcastPool := bapl.NewMulticastPool(networkID, pool, host, host.Network().Peers, signer, &batchVerifier{})
 	mcastPool.Start()
 	defer mcastPool.Stop()
 
-	cert := dag.NewCertifier(mcastPool)
+	// members are known only after the kickoff, until then blocks can't be certified
+	var members atomic.Pointer[quorum.Includers]
+	includers := func(uint64) (*quorum.Includers, error) {
+		includers := members.Load()
+		if includers == nil {
+			return nil, errors.New("members are not known yet")
+		}
+		return includers, nil
+	}
+
+	cert := dag.NewCertifier(mcastPool, includers)
 	hasher := dag.NewHasher()
-	broadcaster := gossip.NewBroadcaster(networkID, signer, cert, hasher, block.UnmarshalBlockID, pSub)
+	broadcaster := gossip.NewBroadcaster(networkID, signer, cert, hasher, block.UnmarshalBlockID, pSub,
+		gossip.WithScorer(scorer))
 
 	err = broadcaster.Start()
 	if err != nil {
@@ -178,10 +207,13 @@ func run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	members.Store(memebers)
 
-	dagger := dag.NewChain(broadcaster, mcastPool, func(uint64) (*quorum.Includers, error) {
-		return memebers, nil
-	}, privKey.PubKey())
+	dagger := dag.NewChain(broadcaster, mcastPool, includers, signerID)
+	lightServer := light.NewServer(networkID, host)
+	lightServer.Start()
+	defer lightServer.Stop()
+	dagger.OnRound(lightServer.Add)
 	dagger.Start()
 	defer dagger.Stop()
 
@@ -215,7 +247,7 @@ func getIdentity() (libp2pcrypto.PrivKey, crypto.PrivKey, error) {
 			return nil, nil, err
 		}
 
-		privKey, _, err := libp2pcrypto.GenerateEd25519Key(rand.Reader)
+		privKey, err := generateKey(keyType)
 		if err != nil {
 			defer f.Close()
 			return nil, nil, err
@@ -254,12 +286,36 @@ func getIdentity() (libp2pcrypto.PrivKey, crypto.PrivKey, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	key := ed25519.PrivateKey(keyRaw)
+	var key crypto.PrivKey
+	switch p2pKey.Type() {
+	case libp2pcrypto.Ed25519:
+		key = ed25519.PrivateKey(keyRaw)
+	case libp2pcrypto.Secp256k1:
+		key, err = secp256k1.BytesToPrivKey(keyRaw)
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported identity key type %s", p2pKey.Type())
+	}
 
-	slog.Info("identity", "key", hex.EncodeToString(key))
+	slog.Info("identity", "type", key.Type(), "key", hex.EncodeToString(keyRaw))
 	return p2pKey, key, nil
 }
 
+func generateKey(keyType string) (libp2pcrypto.PrivKey, error) {
+	switch keyType {
+	case ed25519.KeyType:
+		privKey, _, err := libp2pcrypto.GenerateEd25519Key(rand.Reader)
+		return privKey, err
+	case secp256k1.KeyType:
+		privKey, _, err := libp2pcrypto.GenerateSecp256k1Key(rand.Reader)
+		return privKey, err
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", keyType)
+	}
+}
+
 type batchVerifier struct{}
 
 func (b *batchVerifier) Verify(context.Context, *bapl.Batch) (bool, error) {